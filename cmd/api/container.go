@@ -6,22 +6,40 @@ import (
 	"go-gin-sqlx-template/config"
 	"go-gin-sqlx-template/internal/delivery/http/handler"
 	"go-gin-sqlx-template/internal/delivery/http/router"
+	"go-gin-sqlx-template/internal/integration/storage"
 	"go-gin-sqlx-template/internal/repository/postgres"
+	redisrepo "go-gin-sqlx-template/internal/repository/redis"
 	"go-gin-sqlx-template/internal/usecase/impl"
+	"go-gin-sqlx-template/pkg/auth"
 	"go-gin-sqlx-template/pkg/database"
 	"go-gin-sqlx-template/pkg/logger"
 	"go-gin-sqlx-template/pkg/pubsub"
+	"go-gin-sqlx-template/pkg/utils"
+	"go-gin-sqlx-template/pkg/validation"
 
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/hibiken/asynq"
 )
 
 // Container holds all application dependencies
 type Container struct {
-	Config      config.Config
-	Logger      *logger.Logger
-	DB          *database.Database
-	UserHandler *handler.UserHandler
-	Router      *router.Router
+	Config               config.Config
+	Logger               *logger.Logger
+	DB                   *database.Database
+	UserHandler          *handler.UserHandler
+	AuthHandler          *handler.AuthHandler
+	RoleHandler          *handler.RoleHandler
+	APIKeyHandler        *handler.APIKeyHandler
+	MFAHandler           *handler.MFAHandler
+	SessionHandler       *handler.SessionHandler
+	OIDCHandler          *handler.OIDCHandler
+	ImportHandler        *handler.UserImportHandler
+	AuditLogHandler      *handler.AuditLogHandler
+	AvatarHandler        *handler.AvatarHandler
+	ImpersonationHandler *handler.ImpersonationHandler
+	OrganizationHandler  *handler.OrganizationHandler
+	Router               *router.Router
 }
 
 // NewContainer initializes all dependencies and wires them together
@@ -52,24 +70,96 @@ func NewContainer(cfg config.Config, log *logger.Logger, db *database.Database)
 		DB:       cfg.RedisDB,
 	})
 
+	// Let binding tags validate the inner value of utils.Optional fields
+	// and use the custom rules, field errors are named after the JSON fields
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		utils.RegisterOptionalTypes(v)
+		if err := validation.Register(v, validation.Options{BlockedEmailDomains: cfg.BlockedEmailDomains}); err != nil {
+			log.Fatalf(context.Background(), "Failed to register validators: %v", err)
+		}
+	}
+
+	// Initialize JWT Manager
+	jwtManager, err := auth.NewJWTManager(cfg)
+	if err != nil {
+		log.Fatalf(context.Background(), "Failed to initialize JWT manager: %v", err)
+	}
+
+	// Initialize file storage
+	fileStorage, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf(context.Background(), "Failed to initialize file storage: %v", err)
+	}
+
 	// Repository layer
 	txManager := db.NewTransactionManager()
 	userRepo := postgres.NewUserRepository(db.DB, txManager)
+	roleRepo := postgres.NewRoleRepository(db.DB, txManager)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db.DB, txManager)
+	passwordResetRepo := postgres.NewPasswordResetRepository(db.DB, txManager)
+	emailVerificationRepo := postgres.NewEmailVerificationRepository(db.DB, txManager)
+	sessionRepo := redisrepo.NewSessionRepository(redisClient)
+	throttleRepo := redisrepo.NewThrottleRepository(redisClient)
+	loginAttemptRepo := redisrepo.NewLoginAttemptRepository(redisClient)
+	mfaRepo := postgres.NewMFARepository(db.DB, txManager)
+	mfaChallengeRepo := redisrepo.NewMFAChallengeRepository(redisClient)
+	identityRepo := postgres.NewIdentityRepository(db.DB, txManager)
+	oidcStateRepo := redisrepo.NewOIDCStateRepository(redisClient)
+	userImportRepo := postgres.NewUserImportRepository(db.DB, txManager)
+	auditLogRepo := postgres.NewAuditLogRepository(db.DB, txManager)
+	organizationRepo := postgres.NewOrganizationRepository(db.DB, txManager)
+	userCacheRepo := redisrepo.NewUserCacheRepository(redisClient)
 
 	// Usecase layer
-	userUsecase := impl.NewUserUsecase(userRepo, txManager, asynqClient, pubsubClient, cfg, log)
+	userUsecase := impl.NewUserUsecase(userRepo, emailVerificationRepo, sessionRepo, auditLogRepo, userCacheRepo, txManager, asynqClient, pubsubClient, cfg, log)
+	roleUsecase := impl.NewRoleUsecase(roleRepo, userRepo, auditLogRepo, txManager, log)
+	apiKeyUsecase := impl.NewAPIKeyUsecase(apiKeyRepo, roleRepo, log)
+	mfaUsecase := impl.NewMFAUsecase(userRepo, mfaRepo, auditLogRepo, txManager, cfg, log)
+	sessionUsecase := impl.NewSessionUsecase(sessionRepo, userRepo, auditLogRepo, txManager, log)
+	oidcUsecase := impl.NewOIDCUsecase(userRepo, roleRepo, organizationRepo, sessionRepo, mfaRepo, mfaChallengeRepo, identityRepo, oidcStateRepo, auditLogRepo, userCacheRepo, txManager, jwtManager, cfg, log)
+	auditLogUsecase := impl.NewAuditLogUsecase(auditLogRepo, log)
+	organizationUsecase := impl.NewOrganizationUsecase(organizationRepo, userRepo, auditLogRepo, userCacheRepo, txManager, log)
+	avatarUsecase := impl.NewAvatarUsecase(userRepo, auditLogRepo, userCacheRepo, txManager, fileStorage, asynqClient, cfg, log)
+	userImportUsecase := impl.NewUserImportUsecase(userRepo, userImportRepo, emailVerificationRepo, auditLogRepo, txManager, asynqClient, cfg, log)
+	impersonationUsecase := impl.NewImpersonationUsecase(userRepo, roleRepo, organizationRepo, sessionRepo, mfaRepo, mfaChallengeRepo, auditLogRepo, jwtManager, cfg, log)
+	authUsecase, err := impl.NewAuthUsecase(userRepo, roleRepo, organizationRepo, sessionRepo, passwordResetRepo, emailVerificationRepo, throttleRepo, loginAttemptRepo, mfaRepo, mfaChallengeRepo, auditLogRepo, userCacheRepo, txManager, jwtManager, asynqClient, cfg, log)
+	if err != nil {
+		log.Fatalf(context.Background(), "Failed to initialize auth usecase: %v", err)
+	}
 
 	// Handler layer
-	userHandler := handler.NewUserHandler(userUsecase, redisClient, log)
+	userHandler := handler.NewUserHandler(userUsecase, log)
+	authHandler := handler.NewAuthHandler(authUsecase, log)
+	roleHandler := handler.NewRoleHandler(roleUsecase, log)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase, log)
+	mfaHandler := handler.NewMFAHandler(mfaUsecase, log)
+	sessionHandler := handler.NewSessionHandler(sessionUsecase, log)
+	oidcHandler := handler.NewOIDCHandler(oidcUsecase, log)
+	importHandler := handler.NewUserImportHandler(userImportUsecase, log)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogUsecase, log)
+	avatarHandler := handler.NewAvatarHandler(avatarUsecase, log)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationUsecase, log)
+	organizationHandler := handler.NewOrganizationHandler(organizationUsecase, log)
 
 	// Router
-	r := router.NewRouter(userHandler, log, db, redisClient, cfg)
+	r := router.NewRouter(userHandler, authHandler, roleHandler, apiKeyHandler, mfaHandler, sessionHandler, oidcHandler, importHandler, auditLogHandler, avatarHandler, impersonationHandler, organizationHandler, jwtManager, apiKeyUsecase, sessionUsecase, organizationUsecase, log, db, redisClient, fileStorage, cfg)
 
 	return &Container{
-		Config:      cfg,
-		Logger:      log,
-		DB:          db,
-		UserHandler: userHandler,
-		Router:      r,
+		Config:               cfg,
+		Logger:               log,
+		DB:                   db,
+		UserHandler:          userHandler,
+		AuthHandler:          authHandler,
+		RoleHandler:          roleHandler,
+		APIKeyHandler:        apiKeyHandler,
+		MFAHandler:           mfaHandler,
+		SessionHandler:       sessionHandler,
+		OIDCHandler:          oidcHandler,
+		ImportHandler:        importHandler,
+		AuditLogHandler:      auditLogHandler,
+		AvatarHandler:        avatarHandler,
+		ImpersonationHandler: impersonationHandler,
+		OrganizationHandler:  organizationHandler,
+		Router:               r,
 	}
 }
@@ -35,6 +35,15 @@ import (
 // @BasePath        /api/v1
 // @schemes         http
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Type "Bearer" followed by a space and the access token.
+
+// @securityDefinitions.apikey  APIKeyAuth
+// @in                          header
+// @name                        X-API-Key
+
 func main() {
 	// Initialize logger
 	log := logger.NewLogger()
@@ -4,17 +4,26 @@ import (
 	"context"
 	"fmt"
 	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/integration/mail"
+	"go-gin-sqlx-template/internal/integration/storage"
 	"go-gin-sqlx-template/internal/integration/telegram"
+	"go-gin-sqlx-template/internal/repository/postgres"
+	redisrepo "go-gin-sqlx-template/internal/repository/redis"
+	"go-gin-sqlx-template/internal/usecase/impl"
 	"go-gin-sqlx-template/internal/worker"
 	pubsubworker "go-gin-sqlx-template/internal/worker/pubsub"
+	"go-gin-sqlx-template/pkg/database"
 	"go-gin-sqlx-template/pkg/logger"
 	ps "go-gin-sqlx-template/pkg/pubsub"
 	"go-gin-sqlx-template/pkg/telemetry"
+	"go-gin-sqlx-template/pkg/validation"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/hibiken/asynq"
 )
 
@@ -48,6 +57,24 @@ func main() {
 	// Init Opentelemetry
 	telemetry.InitTracer(cfg, cfg.WorkerName)
 
+	// Init Database
+	db, err := database.NewPostgresDatabase(cfg)
+	if err != nil {
+		loggerInstance.Fatalf(ctx, "Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Init Redis Client, user changes revoke sessions and drop cached responses
+	redisClient, err := database.NewRedisClient(cfg)
+	if err != nil {
+		loggerInstance.Fatalf(ctx, "Failed to connect to Redis: %v", err)
+	}
+	defer redisClient.Client.Close()
+
+	// Init Asynq Client, tasks may enqueue follow-up tasks
+	asynqClient := asynq.NewClient(redisOpt)
+	defer asynqClient.Close()
+
 	// Init PubSub Worker
 	pubsubClient := pubsubWorker(ctx, cfg, loggerInstance)
 
@@ -67,13 +94,64 @@ func main() {
 		},
 	)
 
+	// Import rows are validated with the binding rules of POST /users
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := validation.Register(v, validation.Options{BlockedEmailDomains: cfg.BlockedEmailDomains}); err != nil {
+			loggerInstance.Fatalf(ctx, "Failed to register validators: %v", err)
+		}
+	}
+
 	// Init Dependencies
 	telegramService := telegram.NewTelegramService(cfg.TelegramToken, cfg.TelegramBaseURL)
 	telegramHandler := worker.NewTelegramTaskHandler(loggerInstance, telegramService)
+	mailService := mail.NewMailService(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	emailHandler := worker.NewEmailTaskHandler(loggerInstance, mailService)
+	txManager := db.NewTransactionManager()
+	userRepo := postgres.NewUserRepository(db.DB, txManager)
+	userImportRepo := postgres.NewUserImportRepository(db.DB, txManager)
+	emailVerificationRepo := postgres.NewEmailVerificationRepository(db.DB, txManager)
+	auditLogRepo := postgres.NewAuditLogRepository(db.DB, txManager)
+	sessionRepo := redisrepo.NewSessionRepository(redisClient)
+	userCacheRepo := redisrepo.NewUserCacheRepository(redisClient)
+	userUsecase := impl.NewUserUsecase(userRepo, emailVerificationRepo, sessionRepo, auditLogRepo, userCacheRepo, txManager, asynqClient, pubsubClient, cfg, loggerInstance)
+	userHandler := worker.NewUserTaskHandler(loggerInstance, userUsecase, cfg.DeletedUserRetention)
+	userImportUsecase := impl.NewUserImportUsecase(userRepo, userImportRepo, emailVerificationRepo, auditLogRepo, txManager, asynqClient, cfg, loggerInstance)
+	userImportHandler := worker.NewUserImportTaskHandler(loggerInstance, userImportUsecase)
+	fileStorage, err := storage.New(cfg)
+	if err != nil {
+		loggerInstance.Fatalf(ctx, "Failed to initialize file storage: %v", err)
+	}
+	avatarUsecase := impl.NewAvatarUsecase(userRepo, auditLogRepo, userCacheRepo, txManager, fileStorage, asynqClient, cfg, loggerInstance)
+	avatarHandler := worker.NewAvatarTaskHandler(loggerInstance, avatarUsecase)
 
 	// Register Tasks
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(worker.TypeTelegramMessage, telegramHandler.HandleTelegramMessageTask)
+	mux.HandleFunc(worker.TypePasswordResetEmail, emailHandler.HandlePasswordResetEmailTask)
+	mux.HandleFunc(worker.TypeEmailVerification, emailHandler.HandleEmailVerificationTask)
+	mux.HandleFunc(worker.TypePurgeDeletedUsers, userHandler.HandlePurgeDeletedUsersTask)
+	mux.HandleFunc(worker.TypeUserImport, userImportHandler.HandleUserImportTask)
+	mux.HandleFunc(worker.TypeExpireUserImports, userImportHandler.HandleExpireUserImportsTask)
+	mux.HandleFunc(worker.TypeAvatarThumbnails, avatarHandler.HandleAvatarThumbnailsTask)
+
+	// Register Periodic Tasks. Every scheduler enqueues each task itself, so with several
+	// worker replicas all but one run with WORKER_SCHEDULER_DISABLED.
+	var scheduler *asynq.Scheduler
+	if !cfg.WorkerSchedulerDisabled {
+		scheduler = asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+			Logger: logger.NewAsynqLoggerAdapter(loggerInstance),
+		})
+		purgeSchedule := cfg.DeletedUserPurgeSchedule
+		if purgeSchedule == "" {
+			purgeSchedule = "@daily"
+		}
+		if _, err := scheduler.Register(purgeSchedule, worker.NewPurgeDeletedUsersTask()); err != nil {
+			loggerInstance.Fatalf(ctx, "Failed to register purge deleted users task: %v", err)
+		}
+		if _, err := scheduler.Register("@hourly", worker.NewExpireUserImportsTask()); err != nil {
+			loggerInstance.Fatalf(ctx, "Failed to register expire user imports task: %v", err)
+		}
+	}
 
 	// Run Worker
 	loggerInstance.Info(context.Background(), "Worker server starting...")
@@ -84,9 +162,18 @@ func main() {
 		}
 	}()
 
+	if scheduler != nil {
+		if err := scheduler.Start(); err != nil {
+			loggerInstance.Fatalf(ctx, "Failed to start scheduler: %v", err)
+		}
+	}
+
 	<-ctx.Done()
 	loggerInstance.Info(context.Background(), "shutdown signal received")
 
+	if scheduler != nil {
+		scheduler.Shutdown()
+	}
 	srv.Shutdown()
 	if pubsubClient != nil {
 		pubsubClient.Close()
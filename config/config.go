@@ -1,31 +1,103 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type Config struct {
-	Environment                   string `mapstructure:"ENVIRONMENT"`
-	ServerPort                    string `mapstructure:"SERVER_PORT"`
-	DBHost                        string `mapstructure:"DB_HOST"`
-	DBPort                        string `mapstructure:"DB_PORT"`
-	DBUser                        string `mapstructure:"DB_USER"`
-	DBPassword                    string `mapstructure:"DB_PASSWORD"`
-	DBName                        string `mapstructure:"DB_NAME"`
-	RedisHost                     string `mapstructure:"REDIS_HOST"`
-	RedisPort                     string `mapstructure:"REDIS_PORT"`
-	RedisDB                       int    `mapstructure:"REDIS_DB"`
-	RedisPassword                 string `mapstructure:"REDIS_PASSWORD"`
-	TelegramBaseURL               string `mapstructure:"TELEGRAM_BASE_URL"`
-	TelegramToken                 string `mapstructure:"TELEGRAM_TOKEN"`
-	TelegramChatID                string `mapstructure:"TELEGRAM_CHAT_ID"`
-	ServiceName                   string `mapstructure:"SERVICE_NAME"`
-	WorkerName                    string `mapstructure:"WORKER_NAME"`
-	PubSubEmulatorHost            string `mapstructure:"PUBSUB_EMULATOR_HOST"`
-	PubSubProjectID               string `mapstructure:"PUBSUB_PROJECT_ID"`
-	PubSubCredsFile               string `mapstructure:"PUBSUB_CREDS_FILE"`
-	PubSubTopicUserCreated        string `mapstructure:"PUBSUB_TOPIC_USER_CREATED"`
-	PubSubSubscriptionUserCreated string `mapstructure:"PUBSUB_SUBSCRIPTION_USER_CREATED"`
+	Environment                     string         `mapstructure:"ENVIRONMENT"`
+	ServerPort                      string         `mapstructure:"SERVER_PORT"`
+	DBHost                          string         `mapstructure:"DB_HOST"`
+	DBPort                          string         `mapstructure:"DB_PORT"`
+	DBUser                          string         `mapstructure:"DB_USER"`
+	DBPassword                      string         `mapstructure:"DB_PASSWORD"`
+	DBName                          string         `mapstructure:"DB_NAME"`
+	RedisHost                       string         `mapstructure:"REDIS_HOST"`
+	RedisPort                       string         `mapstructure:"REDIS_PORT"`
+	RedisDB                         int            `mapstructure:"REDIS_DB"`
+	RedisPassword                   string         `mapstructure:"REDIS_PASSWORD"`
+	TelegramBaseURL                 string         `mapstructure:"TELEGRAM_BASE_URL"`
+	TelegramToken                   string         `mapstructure:"TELEGRAM_TOKEN"`
+	TelegramChatID                  string         `mapstructure:"TELEGRAM_CHAT_ID"`
+	ServiceName                     string         `mapstructure:"SERVICE_NAME"`
+	WorkerName                      string         `mapstructure:"WORKER_NAME"`
+	WorkerSchedulerDisabled         bool           `mapstructure:"WORKER_SCHEDULER_DISABLED"`
+	PubSubEmulatorHost              string         `mapstructure:"PUBSUB_EMULATOR_HOST"`
+	PubSubProjectID                 string         `mapstructure:"PUBSUB_PROJECT_ID"`
+	PubSubCredsFile                 string         `mapstructure:"PUBSUB_CREDS_FILE"`
+	PubSubTopicUserCreated          string         `mapstructure:"PUBSUB_TOPIC_USER_CREATED"`
+	PubSubSubscriptionUserCreated   string         `mapstructure:"PUBSUB_SUBSCRIPTION_USER_CREATED"`
+	JWTAlgorithm                    string         `mapstructure:"JWT_ALGORITHM"`
+	JWTSecret                       string         `mapstructure:"JWT_SECRET"`
+	JWTPrivateKeyPath               string         `mapstructure:"JWT_PRIVATE_KEY_PATH"`
+	JWTPublicKeyPath                string         `mapstructure:"JWT_PUBLIC_KEY_PATH"`
+	JWTIssuer                       string         `mapstructure:"JWT_ISSUER"`
+	JWTAccessTokenTTL               time.Duration  `mapstructure:"JWT_ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL                 time.Duration  `mapstructure:"REFRESH_TOKEN_TTL"`
+	SMTPHost                        string         `mapstructure:"SMTP_HOST"`
+	SMTPPort                        string         `mapstructure:"SMTP_PORT"`
+	SMTPUsername                    string         `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword                    string         `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom                        string         `mapstructure:"SMTP_FROM"`
+	PasswordResetURL                string         `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetTokenTTL           time.Duration  `mapstructure:"PASSWORD_RESET_TOKEN_TTL"`
+	RequireEmailVerification        bool           `mapstructure:"REQUIRE_EMAIL_VERIFICATION"`
+	EmailVerificationURL            string         `mapstructure:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationTokenTTL       time.Duration  `mapstructure:"EMAIL_VERIFICATION_TOKEN_TTL"`
+	EmailVerificationResendInterval time.Duration  `mapstructure:"EMAIL_VERIFICATION_RESEND_INTERVAL"`
+	BcryptCost                      int            `mapstructure:"BCRYPT_COST"`
+	DeletedUserRetention            time.Duration  `mapstructure:"DELETED_USER_RETENTION"`
+	DeletedUserPurgeSchedule        string         `mapstructure:"DELETED_USER_PURGE_SCHEDULE"`
+	RequireIfMatch                  bool           `mapstructure:"REQUIRE_IF_MATCH"`
+	LoginMaxFailures                int64          `mapstructure:"LOGIN_MAX_FAILURES"`
+	LoginMaxFailuresPerIP           int64          `mapstructure:"LOGIN_MAX_FAILURES_PER_IP"`
+	LoginFailureWindow              time.Duration  `mapstructure:"LOGIN_FAILURE_WINDOW"`
+	LoginLockoutBase                time.Duration  `mapstructure:"LOGIN_LOCKOUT_BASE"`
+	LoginLockoutMax                 time.Duration  `mapstructure:"LOGIN_LOCKOUT_MAX"`
+	MFAIssuer                       string         `mapstructure:"MFA_ISSUER"`
+	MFAChallengeTTL                 time.Duration  `mapstructure:"MFA_CHALLENGE_TTL"`
+	OIDCProviders                   []OIDCProvider `mapstructure:"OIDC_PROVIDERS"`
+	OIDCStateTTL                    time.Duration  `mapstructure:"OIDC_STATE_TTL"`
+	UserImportMaxBytes              int64          `mapstructure:"USER_IMPORT_MAX_BYTES"`
+	UserImportMaxRows               int            `mapstructure:"USER_IMPORT_MAX_ROWS"`
+	UserImportBatchSize             int            `mapstructure:"USER_IMPORT_BATCH_SIZE"`
+	UserImportRetention             time.Duration  `mapstructure:"USER_IMPORT_RETENTION"`
+	UserExportMaxRows               int            `mapstructure:"USER_EXPORT_MAX_ROWS"`
+	StorageDriver                   string         `mapstructure:"STORAGE_DRIVER"`
+	StorageLocalDir                 string         `mapstructure:"STORAGE_LOCAL_DIR"`
+	StoragePublicURL                string         `mapstructure:"STORAGE_PUBLIC_URL"`
+	StorageS3Endpoint               string         `mapstructure:"STORAGE_S3_ENDPOINT"`
+	StorageS3Region                 string         `mapstructure:"STORAGE_S3_REGION"`
+	StorageS3Bucket                 string         `mapstructure:"STORAGE_S3_BUCKET"`
+	StorageS3AccessKey              string         `mapstructure:"STORAGE_S3_ACCESS_KEY"`
+	StorageS3SecretKey              string         `mapstructure:"STORAGE_S3_SECRET_KEY"`
+	StorageS3PathStyle              bool           `mapstructure:"STORAGE_S3_PATH_STYLE"`
+	AvatarMaxBytes                  int64          `mapstructure:"AVATAR_MAX_BYTES"`
+	ImpersonationTokenTTL           time.Duration  `mapstructure:"IMPERSONATION_TOKEN_TTL"`
+	ErrorFormat                     string         `mapstructure:"ERROR_FORMAT"`
+	ProblemTypeBaseURL              string         `mapstructure:"PROBLEM_TYPE_BASE_URL"`
+	BlockedEmailDomains             []string       `mapstructure:"BLOCKED_EMAIL_DOMAINS"`
+}
+
+// OIDCProvider configures an external OpenID Connect identity provider.
+// OIDC_PROVIDERS holds a JSON array of these objects.
+type OIDCProvider struct {
+	// Name identifies the provider in the login URLs, e.g. /auth/oidc/<name>/login
+	Name string `json:"name" mapstructure:"name"`
+	// IssuerURL is where the discovery document is served, without /.well-known/openid-configuration
+	IssuerURL    string   `json:"issuer_url" mapstructure:"issuer_url"`
+	ClientID     string   `json:"client_id" mapstructure:"client_id"`
+	ClientSecret string   `json:"client_secret" mapstructure:"client_secret"`
+	RedirectURL  string   `json:"redirect_url" mapstructure:"redirect_url"`
+	Scopes       []string `json:"scopes" mapstructure:"scopes"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -43,6 +115,48 @@ func LoadConfig(path string) (config Config, err error) {
 		}
 	}
 
-	err = viper.Unmarshal(&config)
+	err = viper.Unmarshal(&config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		jsonStringHookFunc(),
+	)))
+	if err != nil {
+		return
+	}
+
+	err = config.validate()
 	return
 }
+
+// validate rejects settings that would only fail later, at the first request using them
+func (c Config) validate() error {
+	// 0 falls back to bcrypt.DefaultCost
+	if c.BcryptCost != 0 && (c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost) {
+		return fmt.Errorf("BCRYPT_COST must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, c.BcryptCost)
+	}
+	return nil
+}
+
+// jsonStringHookFunc decodes JSON strings from env vars into slices and structs,
+// so list settings like OIDC_PROVIDERS can be given as a single variable
+func jsonStringHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String {
+			return data, nil
+		}
+		if to.Kind() != reflect.Struct && (to.Kind() != reflect.Slice || to.Elem().Kind() != reflect.Struct) {
+			return data, nil
+		}
+
+		raw := strings.TrimSpace(data.(string))
+		if raw == "" {
+			return reflect.Zero(to).Interface(), nil
+		}
+
+		value := reflect.New(to)
+		if err := json.Unmarshal([]byte(raw), value.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON setting: %w", err)
+		}
+		return value.Elem().Interface(), nil
+	}
+}
@@ -0,0 +1,124 @@
+// Package apperror defines the domain errors shared by repositories, usecases and handlers.
+// Every error has a Kind telling what went wrong, the HTTP layer maps the kind to a status
+// code in one place so handlers only pass errors on.
+package apperror
+
+import (
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind classifies a domain error
+type Kind int
+
+const (
+	// KindInternal is an unexpected failure, its details are never shown to clients
+	KindInternal Kind = iota
+	// KindValidation is a request that is malformed or breaks a business rule
+	KindValidation
+	// KindUnauthorized is a missing, invalid or expired credential
+	KindUnauthorized
+	// KindForbidden is a valid caller that may not perform the operation
+	KindForbidden
+	// KindNotFound is a resource that does not exist or is not visible to the caller
+	KindNotFound
+	// KindConflict is a request that clashes with the current state, such as a taken email
+	KindConflict
+	// KindPreconditionFailed is a conditional request whose precondition does not hold
+	KindPreconditionFailed
+	// KindPreconditionRequired is a request that must be conditional but is not
+	KindPreconditionRequired
+	// KindTooLarge is a payload exceeding the configured size
+	KindTooLarge
+	// KindUnsupportedMediaType is a payload of a type that is not accepted
+	KindUnsupportedMediaType
+	// KindTooManyRequests is a request refused until the caller waits
+	KindTooManyRequests
+	// KindUnavailable is a service outside the application that failed to respond
+	KindUnavailable
+)
+
+// Error is a domain error with a message that is safe to show to clients
+// and an optional cause that is only logged
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+
+	// base is the error Detail extended, errors.Is matches it
+	base *Error
+}
+
+// New returns an error of the kind. The message follows Go conventions,
+// lower case without punctuation, it is capitalized when sent to clients.
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap returns an error of the kind caused by err
+func Wrap(err error, kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// Detail returns an error like base whose message adds a detail that is safe to show
+// to clients, e.g. "invalid merge patch: email cannot be null". errors.Is matches base.
+func Detail(base *Error, detail string) *Error {
+	return &Error{Kind: base.Kind, Message: base.Message + ": " + detail, base: base}
+}
+
+func Validation(message string) *Error {
+	return New(KindValidation, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return e.base != nil && target == e.base
+}
+
+// KindOf returns the kind of the first domain error in the chain of err,
+// errors that are not domain errors are internal
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
+
+// Message returns the client message of the first domain error in the chain of err,
+// capitalized, or an empty string for internal errors
+func Message(err error) string {
+	var appErr *Error
+	if !errors.As(err, &appErr) || appErr.Kind == KindInternal || appErr.Message == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(appErr.Message)
+	return string(unicode.ToUpper(r)) + appErr.Message[size:]
+}
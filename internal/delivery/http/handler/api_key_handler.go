@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+	logger        *logger.Logger
+}
+
+func NewAPIKeyHandler(apiKeyUsecase usecase.APIKeyUsecase, logger *logger.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUsecase: apiKeyUsecase,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKey godoc
+// @Summary      Create API key
+// @Description  Create an API key for service-to-service callers with scopes the caller holds. The key is only returned once.
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.CreateAPIKeyRequest true "Create API Key Request"
+// @Success      201  {object}  utils.Response{data=model.CreateAPIKeyResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req model.CreateAPIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	apiKey, err := h.apiKeyUsecase.CreateAPIKey(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "API key created successfully", apiKey)
+}
+
+// GetAllAPIKeys godoc
+// @Summary      Get all API keys
+// @Description  Get all API keys without their secrets
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.Response{data=[]model.APIKeyResponse}
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) GetAllAPIKeys(c *gin.Context) {
+	apiKeys, err := h.apiKeyUsecase.GetAllAPIKeys(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "API keys retrieved successfully", apiKeys)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke API key
+// @Description  Revoke an API key by ID
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "API Key ID"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid API key ID"))
+		return
+	}
+
+	err = h.apiKeyUsecase.RevokeAPIKey(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "API key revoked successfully", nil)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditLogHandler struct {
+	auditLogUsecase usecase.AuditLogUsecase
+	logger          *logger.Logger
+}
+
+func NewAuditLogHandler(auditLogUsecase usecase.AuditLogUsecase, logger *logger.Logger) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogUsecase: auditLogUsecase,
+		logger:          logger,
+	}
+}
+
+var (
+	// getAuditLogsAllowedFilters defines which filters are allowed for GetAuditLogs
+	getAuditLogsAllowedFilters = []string{"action", "entity_type", "entity_id", "actor_id", "impersonator_id", "request_id", "since", "until"}
+
+	// sort by id, created_at, action
+	// default sort by created_at desc
+	getAuditLogsAllowedSorts = map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"action":     "action",
+	}
+	getAuditLogsDefaultSorts = []utils.SortParams{
+		{Field: "created_at", Direction: "desc"},
+	}
+)
+
+// GetAuditLogs godoc
+// @Summary      Get audit logs
+// @Description  Get the audit trail of changes with pagination and optional filters
+// @Tags         audit-logs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page             query     int     false  "Page number" default(1)
+// @Param        limit            query     int     false  "Limit per page" default(10)
+// @Param        action           query     string  false  "Filter by action, e.g. user.updated"
+// @Param        entity_type      query     string  false  "Filter by entity type, e.g. user"
+// @Param        entity_id        query     int     false  "Filter by entity ID"
+// @Param        actor_id         query     int     false  "Filter by the user who made the change"
+// @Param        impersonator_id  query     int     false  "Filter by the admin who made the change while impersonating"
+// @Param        request_id       query     string  false  "Filter by X-Request-ID"
+// @Param        since            query     string  false  "Changes at or after this RFC 3339 time"
+// @Param        until            query     string  false  "Changes before this RFC 3339 time"
+// @Param        sort             query     string  false  "Sort fields, e.g. created_at:desc"
+// @Success      200  {object}  utils.PaginationResponse{data=[]model.AuditLogResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /audit-logs [get]
+func (h *AuditLogHandler) GetAuditLogs(c *gin.Context) {
+	pagination := utils.ParsePagination(c)
+
+	sort, err := utils.ParseSorts(c, getAuditLogsAllowedSorts, getAuditLogsDefaultSorts)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid sort parameters"))
+		return
+	}
+
+	filters, err := utils.ParseFilters(c, getAuditLogsAllowedFilters)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid query parameters"))
+		return
+	}
+
+	if err := validateAuditLogFilters(filters); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid query parameters"))
+		return
+	}
+
+	logs, total, err := h.auditLogUsecase.GetAuditLogs(c.Request.Context(), pagination, filters, sort)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	paginationMeta := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	utils.PaginatedResponse(c, logs, paginationMeta)
+}
+
+// validateAuditLogFilters rejects IDs and times the database would fail to compare
+func validateAuditLogFilters(filters utils.FilterParams) error {
+	for _, name := range []string{"entity_id", "actor_id", "impersonator_id"} {
+		if value, ok := filters.Get(name); ok {
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				return fmt.Errorf("%s must be an integer", name)
+			}
+		}
+	}
+
+	for _, name := range []string{"since", "until"} {
+		if value, ok := filters.Get(name); ok {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return fmt.Errorf("%s must be an RFC 3339 time", name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	authUsecase usecase.AuthUsecase
+	logger      *logger.Logger
+}
+
+func NewAuthHandler(authUsecase usecase.AuthUsecase, logger *logger.Logger) *AuthHandler {
+	return &AuthHandler{
+		authUsecase: authUsecase,
+		logger:      logger,
+	}
+}
+
+// Login godoc
+// @Summary      Login
+// @Description  Authenticate with email and password and receive an access token. Accounts with two-factor authentication receive an MFA challenge token instead, to be exchanged at /auth/mfa/verify.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.LoginRequest true "Login Request"
+// @Success      200  {object}  utils.Response{data=model.LoginResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      429  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req model.LoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	token, err := h.authUsecase.Login(c.Request.Context(), req, clientInfo(c))
+	if err != nil {
+		var lockedErr *usecase.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			retryAfter := int64(math.Ceil(lockedErr.RetryAfter.Seconds()))
+			c.Header(utils.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+		}
+		c.Error(err)
+		return
+	}
+
+	if token.MFARequired {
+		utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication required", token)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", token)
+}
+
+// VerifyMFA godoc
+// @Summary      Verify two-factor code
+// @Description  Complete a login with the MFA challenge token and a code from the authenticator app or a recovery code
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.VerifyMFARequest true "Verify MFA Request"
+// @Success      200  {object}  utils.Response{data=model.TokenResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req model.VerifyMFARequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	token, err := h.authUsecase.VerifyMFA(c.Request.Context(), req, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", token)
+}
+
+// Refresh godoc
+// @Summary      Refresh tokens
+// @Description  Exchange a refresh token for a new access token and a rotated refresh token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.RefreshTokenRequest true "Refresh Token Request"
+// @Success      200  {object}  utils.Response{data=model.TokenResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req model.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	token, err := h.authUsecase.Refresh(c.Request.Context(), req.RefreshToken, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Token refreshed successfully", token)
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Revoke the refresh token and every token rotated from the same login
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.RefreshTokenRequest true "Refresh Token Request"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req model.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	err := h.authUsecase.Logout(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Logged out successfully", nil)
+}
+
+// ForgotPassword godoc
+// @Summary      Forgot password
+// @Description  Email a password reset link. The response is the same whether or not the email is registered.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.ForgotPasswordRequest true "Forgot Password Request"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      429  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req model.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	if err := h.authUsecase.ForgotPassword(c.Request.Context(), req, clientInfo(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password using a token received by email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.ResetPasswordRequest true "Reset Password Request"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req model.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	err := h.authUsecase.ResetPassword(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Change the password of the current user. Every existing session is revoked and a new token pair is returned.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.ChangePasswordRequest true "Change Password Request"
+// @Success      200  {object}  utils.Response{data=model.TokenResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	// API keys are not tied to a user and cannot change a password
+	userID, ok := auth.UserIDFromContext(c.Request.Context())
+	if !ok || userID == 0 {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req model.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	token, err := h.authUsecase.ChangePassword(c.Request.Context(), userID, req, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password changed successfully", token)
+}
+
+// VerifyEmail godoc
+// @Summary      Verify email
+// @Description  Mark the email address as verified using the token from the verification link
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token query     string  true  "Verification token"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/verify [get]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.Error(apperror.Validation("missing verification token"))
+		return
+	}
+
+	err := h.authUsecase.VerifyEmail(c.Request.Context(), token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Email verified successfully", nil)
+}
+
+// ResendVerification godoc
+// @Summary      Resend verification email
+// @Description  Send a new verification link. The response is the same whether or not the email is registered.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body model.ResendVerificationRequest true "Resend Verification Request"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      429  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/verify/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req model.ResendVerificationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	if err := h.authUsecase.ResendVerification(c.Request.Context(), req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "If the email is registered and not verified yet, a verification link has been sent", nil)
+}
+
+// UnlockAccount godoc
+// @Summary      Unlock account
+// @Description  Lift a lockout caused by failed logins and reset the failed attempt counter of the user
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Router       /users/{id}/unlock [post]
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	err = h.authUsecase.UnlockAccount(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Account unlocked successfully", nil)
+}
+
+// clientInfo describes the client of the request for session tracking and login throttling
+func clientInfo(c *gin.Context) model.ClientInfo {
+	return model.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
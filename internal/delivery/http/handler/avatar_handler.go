@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvatarHandler struct {
+	avatarUsecase usecase.AvatarUsecase
+	logger        *logger.Logger
+}
+
+func NewAvatarHandler(avatarUsecase usecase.AvatarUsecase, logger *logger.Logger) *AvatarHandler {
+	return &AvatarHandler{
+		avatarUsecase: avatarUsecase,
+		logger:        logger,
+	}
+}
+
+// UploadAvatar godoc
+// @Summary      Upload avatar
+// @Description  Upload a JPEG, PNG or GIF image as the avatar of the user. The type is detected from the content. Thumbnails are generated in the background and appear in avatar_thumbnails once ready.
+// @Tags         users
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int   true  "User ID"
+// @Param        avatar  formData  file  true  "Avatar image"
+// @Success      200  {object}  utils.Response{data=model.UserResponse}
+// @Header       200  {string}  ETag  "New version of the user"
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      412  {object}  utils.Response
+// @Failure      413  {object}  utils.Response
+// @Failure      415  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/{id}/avatar [post]
+func (h *AvatarHandler) UploadAvatar(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	data, _, err := readFormFile(c, "avatar", "avatar", h.avatarUsecase.MaxBytes(), usecase.ErrAvatarTooLarge)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	user, err := h.avatarUsecase.UploadAvatar(c.Request.Context(), id, data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header(utils.HeaderETag, utils.FormatETag(user.Version))
+	utils.SuccessResponse(c, http.StatusOK, "Avatar uploaded successfully", user)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImpersonationHandler struct {
+	impersonationUsecase usecase.ImpersonationUsecase
+	logger               *logger.Logger
+}
+
+func NewImpersonationHandler(impersonationUsecase usecase.ImpersonationUsecase, logger *logger.Logger) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationUsecase: impersonationUsecase,
+		logger:               logger,
+	}
+}
+
+// StartImpersonation godoc
+// @Summary      Impersonate user
+// @Description  Issue a short-lived access token to act as the user, without a refresh token. Everything done with it is audited with both the user and the admin. Users holding permissions the caller lacks cannot be impersonated, and the token cannot change the password or 2FA.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      201  {object}  utils.Response{data=model.ImpersonationResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/{id}/impersonate [post]
+func (h *ImpersonationHandler) StartImpersonation(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	token, err := h.impersonationUsecase.StartImpersonation(c.Request.Context(), id, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Impersonation started", token)
+}
+
+// EndImpersonation godoc
+// @Summary      End impersonation
+// @Description  Revoke the impersonation token the request is made with. It stops working immediately.
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/impersonation [delete]
+func (h *ImpersonationHandler) EndImpersonation(c *gin.Context) {
+	if err := h.impersonationUsecase.EndImpersonation(c.Request.Context()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Impersonation ended", nil)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MFAHandler struct {
+	mfaUsecase usecase.MFAUsecase
+	logger     *logger.Logger
+}
+
+func NewMFAHandler(mfaUsecase usecase.MFAUsecase, logger *logger.Logger) *MFAHandler {
+	return &MFAHandler{
+		mfaUsecase: mfaUsecase,
+		logger:     logger,
+	}
+}
+
+// Enroll godoc
+// @Summary      Enroll in two-factor authentication
+// @Description  Generate a TOTP secret and otpauth URI for the current user. 2FA is only enabled after confirming a code.
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.Response{data=model.EnrollMFAResponse}
+// @Failure      401  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/2fa/enroll [post]
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	// API keys are not tied to a user and cannot enroll
+	userID, ok := auth.UserIDFromContext(c.Request.Context())
+	if !ok || userID == 0 {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	enrollment, err := h.mfaUsecase.Enroll(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Scan the QR code and confirm with a code to enable two-factor authentication", enrollment)
+}
+
+// Confirm godoc
+// @Summary      Confirm two-factor authentication
+// @Description  Enable 2FA with a code from the authenticator app. The recovery codes are only returned once.
+// @Tags         mfa
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.ConfirmMFARequest true "Confirm MFA Request"
+// @Success      200  {object}  utils.Response{data=model.ConfirmMFAResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/2fa/confirm [post]
+func (h *MFAHandler) Confirm(c *gin.Context) {
+	userID, ok := auth.UserIDFromContext(c.Request.Context())
+	if !ok || userID == 0 {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req model.ConfirmMFARequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	confirmation, err := h.mfaUsecase.Confirm(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication enabled", confirmation)
+}
+
+// Reset godoc
+// @Summary      Reset two-factor authentication
+// @Description  Remove the 2FA enrollment and recovery codes of a user, e.g. after losing their device
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Router       /users/{id}/2fa [delete]
+func (h *MFAHandler) Reset(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	if err := h.mfaUsecase.Reset(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication reset successfully", nil)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCHandler struct {
+	oidcUsecase usecase.OIDCUsecase
+	logger      *logger.Logger
+}
+
+func NewOIDCHandler(oidcUsecase usecase.OIDCUsecase, logger *logger.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUsecase: oidcUsecase,
+		logger:      logger,
+	}
+}
+
+// ListProviders godoc
+// @Summary      List identity providers
+// @Description  List the configured external OpenID Connect providers
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.Response{data=[]model.OIDCProviderResponse}
+// @Router       /auth/oidc/providers [get]
+func (h *OIDCHandler) ListProviders(c *gin.Context) {
+	providers := h.oidcUsecase.ListProviders(c.Request.Context())
+
+	utils.SuccessResponse(c, http.StatusOK, "Identity providers retrieved successfully", providers)
+}
+
+// Login godoc
+// @Summary      Login with identity provider
+// @Description  Redirect to the provider's authorization endpoint using the authorization code flow with PKCE
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path  string  true  "Provider name"
+// @Success      302
+// @Failure      404  {object}  utils.Response
+// @Failure      502  {object}  utils.Response
+// @Router       /auth/oidc/{provider}/login [get]
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authURL, err := h.oidcUsecase.AuthorizationURL(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback godoc
+// @Summary      Identity provider callback
+// @Description  Complete the login with the code returned by the provider. Accounts are linked to existing users by verified email on first login.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path   string  true   "Provider name"
+// @Param        code      query  string  true   "Authorization code"
+// @Param        state     query  string  true   "State"
+// @Success      200  {object}  utils.Response{data=model.LoginResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /auth/oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	// The provider redirects back with an error when the user denied access
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.Error(apperror.Wrap(errors.New(providerErr), apperror.KindValidation, "login was cancelled at the identity provider"))
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		c.Error(apperror.Validation("missing code or state"))
+		return
+	}
+
+	token, err := h.oidcUsecase.Callback(c.Request.Context(), c.Param("provider"), code, state, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if token.MFARequired {
+		utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication required", token)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", token)
+}
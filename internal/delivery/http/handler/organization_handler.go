@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	organizationUsecase usecase.OrganizationUsecase
+	logger              *logger.Logger
+}
+
+func NewOrganizationHandler(organizationUsecase usecase.OrganizationUsecase, logger *logger.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUsecase: organizationUsecase,
+		logger:              logger,
+	}
+}
+
+var (
+	// sort by id, name, created_at
+	// default sort by id asc
+	getOrganizationsAllowedSorts = map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"created_at": "created_at",
+	}
+	getOrganizationsDefaultSorts = []utils.SortParams{
+		{Field: "id", Direction: "asc"},
+	}
+)
+
+// CreateOrganization godoc
+// @Summary      Create organization
+// @Description  Create a new organization (tenant)
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      model.CreateOrganizationRequest  true  "Create Organization Request"
+// @Success      201  {object}  utils.Response{data=model.OrganizationResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req model.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	organization, err := h.organizationUsecase.CreateOrganization(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Organization created successfully", organization)
+}
+
+// GetAllOrganizations godoc
+// @Summary      Get all organizations
+// @Description  Get all organizations with pagination
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int     false  "Page number" default(1)
+// @Param        limit  query     int     false  "Limit per page" default(10)
+// @Param        sort   query     string  false  "Sort fields, e.g. name:asc"
+// @Success      200  {object}  utils.PaginationResponse{data=[]model.OrganizationResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /organizations [get]
+func (h *OrganizationHandler) GetAllOrganizations(c *gin.Context) {
+	pagination := utils.ParsePagination(c)
+
+	sort, err := utils.ParseSorts(c, getOrganizationsAllowedSorts, getOrganizationsDefaultSorts)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid sort parameters"))
+		return
+	}
+
+	organizations, total, err := h.organizationUsecase.GetAllOrganizations(c.Request.Context(), pagination, sort)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	paginationMeta := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	utils.PaginatedResponse(c, organizations, paginationMeta)
+}
+
+// GetOrganization godoc
+// @Summary      Get organization
+// @Description  Get an organization by ID
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Organization ID"
+// @Success      200  {object}  utils.Response{data=model.OrganizationResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid organization ID"))
+		return
+	}
+
+	organization, err := h.organizationUsecase.GetOrganization(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Organization retrieved successfully", organization)
+}
+
+// AddMember godoc
+// @Summary      Add organization member
+// @Description  Add an existing user to an organization
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Organization ID"
+// @Param        request  body      model.AddOrganizationMemberRequest  true  "Add Member Request"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid organization ID"))
+		return
+	}
+
+	var req model.AddOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	if err := h.organizationUsecase.AddMember(c.Request.Context(), id, req.UserID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Member added successfully", nil)
+}
+
+// RemoveMember godoc
+// @Summary      Remove organization member
+// @Description  Remove a user from an organization. The user account itself is kept.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Organization ID"
+// @Param        user_id  path      int  true  "User ID"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /organizations/{id}/members/{user_id} [delete]
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid organization ID"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	if err := h.organizationUsecase.RemoveMember(c.Request.Context(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Member removed successfully", nil)
+}
+
+// GetMyOrganizations godoc
+// @Summary      List my organizations
+// @Description  List the organizations the current user is a member of. The first one is used when a request sends no X-Organization-ID header.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.Response{data=[]model.OrganizationResponse}
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/organizations [get]
+func (h *OrganizationHandler) GetMyOrganizations(c *gin.Context) {
+	// API keys are not tied to a user and belong to no organization
+	userID, ok := auth.UserIDFromContext(c.Request.Context())
+	if !ok || userID == 0 {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	organizations, err := h.organizationUsecase.GetUserOrganizations(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Organizations retrieved successfully", organizations)
+}
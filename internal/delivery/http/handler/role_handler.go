@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleUsecase usecase.RoleUsecase
+	logger      *logger.Logger
+}
+
+func NewRoleHandler(roleUsecase usecase.RoleUsecase, logger *logger.Logger) *RoleHandler {
+	return &RoleHandler{
+		roleUsecase: roleUsecase,
+		logger:      logger,
+	}
+}
+
+// GetAllRoles godoc
+// @Summary      Get all roles
+// @Description  Get all roles with the permissions they grant
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.Response{data=[]model.RoleResponse}
+// @Failure      401  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /roles [get]
+func (h *RoleHandler) GetAllRoles(c *gin.Context) {
+	roles, err := h.roleUsecase.GetAllRoles(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roles retrieved successfully", roles)
+}
+
+// GetUserRoles godoc
+// @Summary      Get user roles
+// @Description  Get the roles assigned to a user
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  utils.Response{data=[]model.RoleResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Router       /users/{id}/roles [get]
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	roles, err := h.roleUsecase.GetUserRoles(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User roles retrieved successfully", roles)
+}
+
+// AssignRole godoc
+// @Summary      Assign role
+// @Description  Assign a role to a user. The caller must hold every permission of the role.
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "User ID"
+// @Param        request  body      model.AssignRoleRequest  true  "Assign Role Request"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/{id}/roles [post]
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	var req model.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	err = h.roleUsecase.AssignRole(c.Request.Context(), id, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role assigned successfully", nil)
+}
+
+// RemoveRole godoc
+// @Summary      Remove role
+// @Description  Remove a role from a user. The caller must hold every permission of the role.
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int     true  "User ID"
+// @Param        role  path      string  true  "Role name"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/{id}/roles/{role} [delete]
+func (h *RoleHandler) RemoveRole(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	err = h.roleUsecase.RemoveRole(c.Request.Context(), id, c.Param("role"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role removed successfully", nil)
+}
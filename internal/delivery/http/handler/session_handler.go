@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionUsecase usecase.SessionUsecase
+	logger         *logger.Logger
+}
+
+func NewSessionHandler(sessionUsecase usecase.SessionUsecase, logger *logger.Logger) *SessionHandler {
+	return &SessionHandler{
+		sessionUsecase: sessionUsecase,
+		logger:         logger,
+	}
+}
+
+// ListSessions godoc
+// @Summary      List sessions
+// @Description  List the devices the current user is signed in on, most recently used first
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.Response{data=[]model.SessionResponse}
+// @Failure      401  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/sessions [get]
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	// API keys are not tied to a user and have no sessions
+	claims, ok := auth.ClaimsFromContext(c.Request.Context())
+	if !ok || claims.UserID == 0 {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	sessions, err := h.sessionUsecase.ListSessions(c.Request.Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke session
+// @Description  Sign the current user out of one device. Access tokens of the session stop working immediately.
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Session ID"
+// @Success      200  {object}  utils.Response
+// @Failure      401  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, ok := auth.UserIDFromContext(c.Request.Context())
+	if !ok || userID == 0 {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	err := h.sessionUsecase.RevokeSession(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeUserSessions godoc
+// @Summary      Revoke all sessions of a user
+// @Description  Sign a user out of every device. Access tokens of the sessions stop working immediately.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  utils.Response
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Router       /users/{id}/sessions [delete]
+func (h *SessionHandler) RevokeUserSessions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
+	}
+
+	if err := h.sessionUsecase.RevokeAllSessions(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sessions revoked successfully", nil)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"go-gin-sqlx-template/internal/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left in the request body for the multipart
+// boundaries, part headers and other form fields around an uploaded file
+const multipartOverhead = 64 << 10
+
+// readFormFile reads the named file of a multipart request of at most maxBytes.
+// The request body is limited before it is parsed, so an oversized upload fails with
+// tooLarge without being buffered. Missing or unreadable files are validation errors
+// naming the file with label, e.g. "missing avatar file".
+func readFormFile(c *gin.Context, name, label string, maxBytes int64, tooLarge error) ([]byte, string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+multipartOverhead)
+
+	fileHeader, err := c.FormFile(name)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, "", tooLarge
+		}
+		return nil, "", apperror.Wrap(err, apperror.KindValidation, "missing "+label+" file")
+	}
+	if fileHeader.Size > maxBytes {
+		return nil, "", tooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, "", apperror.Wrap(err, apperror.KindValidation, "invalid "+label+" file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return nil, "", apperror.Wrap(err, apperror.KindValidation, "invalid "+label+" file")
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, "", tooLarge
+	}
+	return data, fileHeader.Filename, nil
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/csv"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+)
+
+func TestCSVUserExportEscapesFormulas(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"=HYPERLINK(\"http://evil.example\",\"click\")", "'=HYPERLINK(\"http://evil.example\",\"click\")"},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"\r=1+1", "'\r=1+1"},
+		{"Jane Doe", "Jane Doe"},
+		{"Jane=Doe", "Jane=Doe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			export := newCSVUserExportWriter(recorder)
+			err := export.writeUser(model.UserResponse{ID: 1, Email: tt.name + "@example.com", Name: tt.name, CreatedAt: time.Now(), UpdatedAt: time.Now()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := export.flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			record, err := csv.NewReader(recorder.Body).Read()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if record[2] != tt.want {
+				t.Errorf("name cell = %q, want %q", record[2], tt.want)
+			}
+			if record[1] != tt.want+"@example.com" {
+				t.Errorf("email cell = %q, want %q", record[1], tt.want+"@example.com")
+			}
+		})
+	}
+}
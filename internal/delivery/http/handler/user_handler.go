@@ -1,29 +1,31 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 
+	"go-gin-sqlx-template/internal/apperror"
 	"go-gin-sqlx-template/internal/delivery/http/middleware"
 	"go-gin-sqlx-template/internal/model"
 	"go-gin-sqlx-template/internal/usecase"
-	"go-gin-sqlx-template/pkg/database"
 	"go-gin-sqlx-template/pkg/logger"
 	"go-gin-sqlx-template/pkg/utils"
+	"go-gin-sqlx-template/pkg/validation"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
 	userUsecase usecase.UserUsecase
-	redisClient *database.RedisClient
 	logger      *logger.Logger
 }
 
-func NewUserHandler(userUsecase usecase.UserUsecase, redisClient *database.RedisClient, logger *logger.Logger) *UserHandler {
+func NewUserHandler(userUsecase usecase.UserUsecase, logger *logger.Logger) *UserHandler {
 	return &UserHandler{
 		userUsecase: userUsecase,
-		redisClient: redisClient,
 		logger:      logger,
 	}
 }
@@ -37,19 +39,20 @@ func NewUserHandler(userUsecase usecase.UserUsecase, redisClient *database.Redis
 // @Param        request body model.CreateUserRequest true "Create User Request"
 // @Success      201  {object}  utils.Response{data=model.UserResponse}
 // @Failure      400  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
 // @Failure      500  {object}  utils.Response
 // @Router       /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req model.CreateUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
 		return
 	}
 
 	user, err := h.userUsecase.CreateUser(c.Request.Context(), req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create user", err)
+		c.Error(err)
 		return
 	}
 
@@ -62,25 +65,29 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      int  true  "User ID"
 // @Success      200  {object}  utils.Response{data=model.UserResponse}
+// @Header       200  {string}  ETag  "Current version of the user"
 // @Failure      400  {object}  utils.Response
 // @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
 // @Router       /users/{id} [get]
 func (h *UserHandler) GetUserByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
 		return
 	}
 
 	user, err := h.userUsecase.GetUserByID(c.Request.Context(), id)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "User not found", err)
+		c.Error(err)
 		return
 	}
 
+	c.Header(utils.HeaderETag, utils.FormatETag(user.Version))
 	utils.SuccessResponse(c, http.StatusOK, "User retrieved successfully", user)
 }
 
@@ -90,18 +97,21 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        page   query     int     false  "Page number" default(1)
 // @Param        limit  query     int     false  "Limit per page" default(10)
 // @Param        name   query     string  false  "Filter by name (partial match)"
 // @Param        email  query     string  false  "Filter by email (partial match)"
+// @Param        include_deleted  query  bool  false  "Include soft-deleted users (requires users:restore)"
 // @Success      200  {object}  utils.PaginationResponse{data=[]model.UserResponse}
 // @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
 // @Failure      500  {object}  utils.Response
 // @Router       /users [get]
 var (
 	// getAllUsersAllowedFilters defines which filters are allowed for GetAllUsers
-	// only allow name and email
-	getAllUsersAllowedFilters = []string{"name", "email"}
+	// only allow name, email and include_deleted
+	getAllUsersAllowedFilters = []string{"name", "email", "include_deleted"}
 
 	// sort by id, email, name, created_at, updated_at
 	// default sort by created_at desc
@@ -116,6 +126,9 @@ var (
 	getAllUsersDefaultSorts = []utils.SortParams{
 		{Field: "created_at", Direction: "desc"},
 	}
+
+	// exportUsersAllowedFilters are the GetAllUsers filters plus the export format
+	exportUsersAllowedFilters = append(slices.Clone(getAllUsersAllowedFilters), "format")
 )
 
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
@@ -125,14 +138,18 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 	// Parse sort parameters
 	sort, err := utils.ParseSorts(c, getAllUsersAllowedSorts, getAllUsersDefaultSorts)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid sort parameters", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid sort parameters"))
 		return
 	}
 
-	// Parse filter parameters (only allow name and email)
+	// Parse filter parameters (only allow name, email and include_deleted)
 	filters, err := utils.ParseFilters(c, getAllUsersAllowedFilters)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid query parameters"))
+		return
+	}
+
+	if !authorizeIncludeDeleted(c, filters) {
 		return
 	}
 
@@ -144,7 +161,7 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 		sort,
 	)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get users", err)
+		c.Error(err)
 		return
 	}
 
@@ -153,77 +170,350 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 	utils.PaginatedResponse(c, users, paginationMeta)
 }
 
+// ExportUsers godoc
+// @Summary      Export users
+// @Description  Stream every user matching the filters as CSV or NDJSON, in the requested order and up to the configured row cap. Takes the same filters and sorts as GET /users.
+// @Tags         users
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format  query     string  false  "Export format" Enums(csv, ndjson) default(csv)
+// @Param        name    query     string  false  "Filter by name (partial match)"
+// @Param        email   query     string  false  "Filter by email (partial match)"
+// @Param        include_deleted  query  bool  false  "Include soft-deleted users (requires users:restore)"
+// @Param        sort    query     string  false  "Sort fields, e.g. name:asc,created_at:desc"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/export [get]
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	sort, err := utils.ParseSorts(c, getAllUsersAllowedSorts, getAllUsersDefaultSorts)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid sort parameters"))
+		return
+	}
+
+	filters, err := utils.ParseFilters(c, exportUsersAllowedFilters)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid query parameters"))
+		return
+	}
+	delete(filters, "format")
+
+	if !authorizeIncludeDeleted(c, filters) {
+		return
+	}
+
+	var export userExportWriter
+	switch c.DefaultQuery("format", "csv") {
+	case "csv":
+		export = newCSVUserExportWriter(c.Writer)
+	case "ndjson":
+		export = newNDJSONUserExportWriter(c.Writer)
+	default:
+		c.Error(apperror.Validation("invalid query parameters: format must be csv or ndjson"))
+		return
+	}
+
+	// Headers are sent with the first row, so a failing query can still answer with an error
+	started := false
+	start := func() error {
+		started = true
+		c.Header(utils.HeaderContentType, export.contentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, export.extension()))
+		c.Status(http.StatusOK)
+		return export.writeHeader()
+	}
+
+	rows := 0
+	err = h.userUsecase.ExportUsers(c.Request.Context(), filters, sort, func(user model.UserResponse) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := export.writeUser(user); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%userExportFlushEvery == 0 {
+			return export.flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			c.Error(err)
+			return
+		}
+		// The status is already sent, the client sees a truncated file
+		h.logger.Errorf(c.Request.Context(), "Failed to export users after %d rows: %v", rows, err)
+		c.Abort()
+		return
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			h.logger.Errorf(c.Request.Context(), "Failed to export users: %v", err)
+			return
+		}
+	}
+	if err := export.flush(); err != nil {
+		h.logger.Errorf(c.Request.Context(), "Failed to export users: %v", err)
+		return
+	}
+
+	c.Set(utils.CtxResponseMessageKey, fmt.Sprintf("Exported %d users", rows))
+}
+
+// authorizeIncludeDeleted validates the include_deleted filter and records the error on the context if it fails.
+// Deleted users are only visible to callers allowed to restore them.
+func authorizeIncludeDeleted(c *gin.Context, filters utils.FilterParams) bool {
+	includeDeleted, ok := filters.Get("include_deleted")
+	if !ok {
+		return true
+	}
+	if includeDeleted != "true" && includeDeleted != "false" {
+		c.Error(apperror.Validation("invalid query parameters: include_deleted must be true or false"))
+		return false
+	}
+	if claims, ok := middleware.GetClaims(c); includeDeleted == "true" && (!ok || !claims.HasPermission("users:restore")) {
+		c.Error(apperror.Forbidden("forbidden"))
+		return false
+	}
+	return true
+}
+
 // UpdateUser godoc
-// @Summary      Update user
-// @Description  Update user details by ID
+// @Summary      Replace user
+// @Description  Replace user details by ID. Every field is required, use PATCH for partial updates.
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id       path      int  true  "User ID"
 // @Param        request  body      model.UpdateUserRequest  true  "Update User Request"
+// @Param        If-Match  header    string  false  "ETag of the version being modified"
 // @Success      200  {object}  utils.Response{data=model.UserResponse}
+// @Header       200  {string}  ETag  "New version of the user"
 // @Failure      400  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
+// @Failure      412  {object}  utils.Response
+// @Failure      428  {object}  utils.Response
 // @Failure      500  {object}  utils.Response
 // @Router       /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
 		return
 	}
 
 	var req model.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
 		return
 	}
 
-	user, err := h.userUsecase.UpdateUser(c.Request.Context(), id, req)
+	ifMatch := utils.ParseIfMatch(c.GetHeader(utils.HeaderIfMatch))
+	user, err := h.userUsecase.UpdateUser(c.Request.Context(), id, req, ifMatch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update user", err)
+		c.Error(err)
 		return
 	}
 
-	// Invalidate cache
-	cacheKey := middleware.GetCacheKey(c)
-	if err := h.redisClient.Client.Del(c.Request.Context(), cacheKey).Err(); err != nil {
-		h.logger.Errorf(c.Request.Context(), "failed to delete cache: %v", err)
+	c.Header(utils.HeaderETag, utils.FormatETag(user.Version))
+	utils.SuccessResponse(c, http.StatusOK, "User updated successfully", user)
+}
+
+// PatchUser godoc
+// @Summary      Patch user
+// @Description  Partially update a user with a JSON Merge Patch (RFC 7396) document. Absent fields are left unchanged.
+// @Tags         users
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "User ID"
+// @Param        request  body      model.PatchUserRequest  true  "Merge Patch Document"
+// @Param        If-Match  header    string  false  "ETag of the version being modified"
+// @Success      200  {object}  utils.Response{data=model.UserResponse}
+// @Header       200  {string}  ETag  "New version of the user"
+// @Failure      400  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
+// @Failure      412  {object}  utils.Response
+// @Failure      415  {object}  utils.Response
+// @Failure      428  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
 	}
 
+	// Plain JSON is accepted too, clients rarely send the merge patch media type
+	if contentType := c.ContentType(); contentType != utils.ContentTypeMergePatchJson && contentType != utils.ContentTypeJson {
+		c.Error(apperror.New(apperror.KindUnsupportedMediaType, "content type must be "+utils.ContentTypeMergePatchJson))
+		return
+	}
+
+	var req model.PatchUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	ifMatch := utils.ParseIfMatch(c.GetHeader(utils.HeaderIfMatch))
+	user, err := h.userUsecase.PatchUser(c.Request.Context(), id, req, ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header(utils.HeaderETag, utils.FormatETag(user.Version))
 	utils.SuccessResponse(c, http.StatusOK, "User updated successfully", user)
 }
 
 // DeleteUser godoc
 // @Summary      Delete user
-// @Description  Delete user by ID
+// @Description  Soft delete user by ID. The user can be restored until it is purged after the retention period.
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      int  true  "User ID"
+// @Param        If-Match  header    string  false  "ETag of the version being modified"
 // @Success      200  {object}  utils.Response
 // @Failure      400  {object}  utils.Response
+// @Failure      412  {object}  utils.Response
+// @Failure      428  {object}  utils.Response
 // @Failure      500  {object}  utils.Response
 // @Router       /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
 		return
 	}
 
-	err = h.userUsecase.DeleteUser(c.Request.Context(), id)
+	ifMatch := utils.ParseIfMatch(c.GetHeader(utils.HeaderIfMatch))
+	err = h.userUsecase.DeleteUser(c.Request.Context(), id, ifMatch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete user", err)
+		c.Error(err)
 		return
 	}
 
-	// Invalidate cache
-	cacheKey := middleware.GetCacheKey(c)
-	if err := h.redisClient.Client.Del(c.Request.Context(), cacheKey).Err(); err != nil {
-		h.logger.Errorf(c.Request.Context(), "failed to delete cache: %v", err)
+	utils.SuccessResponse(c, http.StatusOK, "User deleted successfully", nil)
+}
+
+// RestoreUser godoc
+// @Summary      Restore user
+// @Description  Restore a soft-deleted user by ID
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  utils.Response{data=model.UserResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      409  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid user ID"))
+		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "User deleted successfully", nil)
+	user, err := h.userUsecase.RestoreUser(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header(utils.HeaderETag, utils.FormatETag(user.Version))
+	utils.SuccessResponse(c, http.StatusOK, "User restored successfully", user)
+}
+
+// BatchUsers godoc
+// @Summary      Batch user operations
+// @Description  Create, update (JSON Merge Patch) or delete many users in one request. With atomic=true every operation runs in one transaction and a failure rolls all of them back, otherwise each operation is applied on its own. Every operation gets a result with the status code it would have had as a single request.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.BatchUserRequest true "Batch Request"
+// @Success      200  {object}  utils.Response{data=model.BatchUserResponse}
+// @Success      207  {object}  utils.Response{data=model.BatchUserResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Router       /users/batch [post]
+func (h *UserHandler) BatchUsers(c *gin.Context) {
+	var req model.BatchUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid request body"))
+		return
+	}
+
+	results := h.userUsecase.BatchUsers(c.Request.Context(), req)
+
+	response := model.BatchUserResponse{
+		Atomic:  req.Atomic,
+		Results: make([]model.BatchUserResult, len(results)),
+	}
+	locale := validation.Locale(c.GetHeader(utils.HeaderAcceptLanguage))
+	for i, result := range results {
+		op := req.Operations[i]
+		item := model.BatchUserResult{
+			Index: i,
+			Op:    op.Op,
+			Data:  result.User,
+		}
+
+		if result.Err != nil {
+			item.Status, item.Error = batchErrorStatus(result.Err)
+			item.Errors = validation.FieldErrors(result.Err, locale)
+			if item.Status == http.StatusInternalServerError {
+				h.logger.Errorf(c.Request.Context(), "Batch operation %d failed: %v", i, result.Err)
+			}
+			response.Failed++
+		} else {
+			item.Status = http.StatusOK
+			if op.Op == model.BatchOpCreate {
+				item.Status = http.StatusCreated
+			}
+			response.Succeeded++
+		}
+		response.Results[i] = item
+	}
+
+	if response.Failed > 0 {
+		message := "Batch completed with errors"
+		if req.Atomic {
+			message = "Batch rolled back"
+		}
+		utils.SuccessResponse(c, http.StatusMultiStatus, message, response)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batch completed successfully", response)
+}
+
+// batchErrorStatus maps the error of a batch operation to the status and message of the single endpoint
+func batchErrorStatus(err error) (int, string) {
+	if errors.Is(err, usecase.ErrBatchRolledBack) {
+		return http.StatusFailedDependency, "Rolled back because another operation failed"
+	}
+
+	return middleware.ErrorStatus(err)
 }
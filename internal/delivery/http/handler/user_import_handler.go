@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserImportHandler struct {
+	userImportUsecase usecase.UserImportUsecase
+	logger            *logger.Logger
+}
+
+func NewUserImportHandler(userImportUsecase usecase.UserImportUsecase, logger *logger.Logger) *UserImportHandler {
+	return &UserImportHandler{
+		userImportUsecase: userImportUsecase,
+		logger:            logger,
+	}
+}
+
+// CreateImport godoc
+// @Summary      Import users
+// @Description  Upload a CSV (header email,name,password) or NDJSON file of users to create in the background. Rows are validated like POST /users. Poll the returned import for progress and per-row errors.
+// @Tags         users
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file    formData  file    true   "CSV or NDJSON file"
+// @Param        format  formData  string  false  "File format, detected from the file extension when omitted" Enums(csv, ndjson)
+// @Success      202  {object}  utils.Response{data=model.UserImportResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      413  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/import [post]
+func (h *UserImportHandler) CreateImport(c *gin.Context) {
+	data, filename, err := readFormFile(c, "file", "import", h.userImportUsecase.MaxBytes(), usecase.ErrImportTooLarge)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	format := importFormat(c.PostForm("format"), filename)
+	if format == "" {
+		c.Error(apperror.Validation("unsupported import format, use csv or ndjson"))
+		return
+	}
+
+	userImport, err := h.userImportUsecase.CreateImport(c.Request.Context(), filepath.Base(filename), format, data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Import accepted", userImport)
+}
+
+// GetImport godoc
+// @Summary      Get user import
+// @Description  Get the status, progress and per-row errors of a user import
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Import ID"
+// @Success      200  {object}  utils.Response{data=model.UserImportResponse}
+// @Failure      400  {object}  utils.Response
+// @Failure      403  {object}  utils.Response
+// @Failure      404  {object}  utils.Response
+// @Failure      500  {object}  utils.Response
+// @Router       /users/import/{id} [get]
+func (h *UserImportHandler) GetImport(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.Error(apperror.Wrap(err, apperror.KindValidation, "invalid import ID"))
+		return
+	}
+
+	userImport, err := h.userImportUsecase.GetImport(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Import retrieved successfully", userImport)
+}
+
+// importFormat returns the requested format, or the one matching the file extension
+func importFormat(format, filename string) string {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".csv":
+			format = model.UserImportFormatCSV
+		case ".ndjson", ".jsonl":
+			format = model.UserImportFormatNDJSON
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case model.UserImportFormatCSV:
+		return model.UserImportFormatCSV
+	case model.UserImportFormatNDJSON:
+		return model.UserImportFormatNDJSON
+	default:
+		return ""
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"go-gin-sqlx-template/pkg/database"
 	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/tenant"
+	"go-gin-sqlx-template/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,16 +39,23 @@ func CacheMiddleware(redisClient *database.RedisClient, ttl time.Duration, logge
 		}
 
 		key := GetCacheKey(c)
+		bodyField, etagField := cacheFields(c.Request.Context())
 		ctx := context.Background()
 
-		// Check cache
-		val, err := redisClient.Client.Get(ctx, key).Result()
+		// Check cache, the ETag is cached alongside the body so conditional
+		// requests keep working on hits
+		cached, err := redisClient.Client.HMGet(ctx, key, bodyField, etagField).Result()
 		if err == nil {
-			c.Header("Content-Type", "application/json")
-			c.Header("X-Cache", "HIT")
-			c.String(http.StatusOK, val)
-			c.Abort()
-			return
+			if body, ok := cached[0].(string); ok {
+				c.Header("Content-Type", "application/json")
+				c.Header("X-Cache", "HIT")
+				if etag, _ := cached[1].(string); etag != "" {
+					c.Header(utils.HeaderETag, etag)
+				}
+				c.String(http.StatusOK, body)
+				c.Abort()
+				return
+			}
 		}
 
 		// Cache miss
@@ -57,13 +66,27 @@ func CacheMiddleware(redisClient *database.RedisClient, ttl time.Duration, logge
 
 		// Save to cache if status is 200
 		if c.Writer.Status() == http.StatusOK {
-			if err := redisClient.Client.Set(ctx, key, w.body.String(), ttl).Err(); err != nil {
+			pipe := redisClient.Client.TxPipeline()
+			pipe.HSet(ctx, key, bodyField, w.body.String(), etagField, c.Writer.Header().Get(utils.HeaderETag))
+			pipe.Expire(ctx, key, ttl)
+			if _, err := pipe.Exec(ctx); err != nil {
 				logger.Errorf(c.Request.Context(), "failed to cache response: %v", err)
 			}
 		}
 	}
 }
 
+// cacheFields returns the hash fields a response is cached in. Responses of requests scoped
+// to an organization are cached per organization, so a hit never skips the tenant scoping of
+// the handler, while deleting the key still invalidates every organization at once.
+func cacheFields(ctx context.Context) (string, string) {
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		prefix := fmt.Sprintf("org:%d:", organizationID)
+		return prefix + "body", prefix + "etag"
+	}
+	return "body", "etag"
+}
+
 func GetCacheKey(c *gin.Context) string {
-	return fmt.Sprintf("cache:%s", c.Request.URL.RequestURI())
+	return utils.CacheKey(c.Request.URL.RequestURI())
 }
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+	"go-gin-sqlx-template/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// kindStatus is the HTTP status code of each kind of domain error
+var kindStatus = map[apperror.Kind]int{
+	apperror.KindValidation:           http.StatusBadRequest,
+	apperror.KindUnauthorized:         http.StatusUnauthorized,
+	apperror.KindForbidden:            http.StatusForbidden,
+	apperror.KindNotFound:             http.StatusNotFound,
+	apperror.KindConflict:             http.StatusConflict,
+	apperror.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	apperror.KindPreconditionRequired: http.StatusPreconditionRequired,
+	apperror.KindTooLarge:             http.StatusRequestEntityTooLarge,
+	apperror.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	apperror.KindTooManyRequests:      http.StatusTooManyRequests,
+	apperror.KindUnavailable:          http.StatusBadGateway,
+}
+
+// Error formats of the ERROR_FORMAT setting
+const (
+	ErrorFormatEnvelope = "envelope"
+	ErrorFormatProblem  = "problem"
+)
+
+// environmentProduction is the ENVIRONMENT value of production deployments
+const environmentProduction = "production"
+
+// ErrorFormat stores the utils.ErrorOptions of the configuration in the request.
+// With ERROR_FORMAT=problem every error is sent as RFC 7807 problem details, otherwise
+// only to callers accepting application/problem+json. In production the error text
+// of 5xx responses is never sent.
+func ErrorFormat(cfg config.Config) gin.HandlerFunc {
+	options := utils.ErrorOptions{
+		ProblemDetails:     strings.EqualFold(cfg.ErrorFormat, ErrorFormatProblem),
+		ProblemTypeBaseURL: cfg.ProblemTypeBaseURL,
+		HideInternalErrors: strings.EqualFold(cfg.Environment, environmentProduction),
+	}
+
+	return func(c *gin.Context) {
+		c.Set(utils.CtxErrorOptionsKey, options)
+		c.Next()
+	}
+}
+
+// ErrorStatus returns the status code and client message of err. Errors that are
+// not domain errors are internal server errors whose details are not disclosed.
+func ErrorStatus(err error) (int, string) {
+	status, ok := kindStatus[apperror.KindOf(err)]
+	if !ok {
+		return http.StatusInternalServerError, "Internal server error"
+	}
+	return status, apperror.Message(err)
+}
+
+// ErrorHandler writes the response of handlers that failed with c.Error(err).
+// It must be registered before the routes, responses already written are left as they are.
+func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status, message := ErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Errorf(c.Request.Context(), "Request failed: %v", err)
+			// The error text is only shown outside production, see ErrorFormat
+			utils.ErrorResponse(c, status, message, err)
+			return
+		}
+
+		// Binding errors list the failed fields in the language of the caller. Other errors
+		// are explained by their message alone, the text of the errors they wrap may hold
+		// internals such as constraint names and is never sent.
+		var detail error
+		if apperror.KindOf(err) == apperror.KindValidation {
+			if fields := validation.FieldErrors(err, validation.Locale(c.GetHeader(utils.HeaderAcceptLanguage))); fields != nil {
+				detail = &validation.Error{Err: errors.New(message), Fields: fields}
+			}
+		}
+		utils.ErrorResponse(c, status, message, detail)
+	}
+}
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
 	"time"
 
 	"go-gin-sqlx-template/pkg/logger"
@@ -9,6 +12,47 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxRequestIDLength bounds the X-Request-ID accepted from callers
+const maxRequestIDLength = 100
+
+// RequestID reuses the X-Request-ID header of the caller or generates one, echoes it
+// in the response and stores it with the client IP in the request context
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(utils.HeaderRequestID)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Header(utils.HeaderRequestID, requestID)
+		c.Request = c.Request.WithContext(utils.WithRequestInfo(c.Request.Context(), utils.RequestInfo{
+			RequestID: requestID,
+			IPAddress: c.ClientIP(),
+		}))
+		c.Next()
+	}
+}
+
+// validRequestID accepts short printable ASCII IDs, anything else is replaced
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns 16 random bytes in hex
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 func RequestLogger(log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
@@ -18,7 +62,9 @@ func RequestLogger(log *logger.Logger) gin.HandlerFunc {
 		duration := time.Since(startTime)
 
 		// Create logger with request-specific fields
+		info, _ := utils.RequestInfoFromContext(c.Request.Context())
 		requestLogger := log.WithFields(map[string]any{
+			"request_id":  info.RequestID,
 			"method":      c.Request.Method,
 			"path":        c.Request.URL.Path,
 			"status_code": c.Writer.Status(),
@@ -42,21 +88,10 @@ func Recovery(log *logger.Logger) gin.HandlerFunc {
 		defer func() {
 			if err := recover(); err != nil {
 				log.Errorf(c.Request.Context(), "Panic recovered: %v", err)
-				c.JSON(500, gin.H{
-					"success": false,
-					"message": "Internal server error",
-				})
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Internal server error", nil)
 				c.Abort()
 			}
 		}()
 		c.Next()
 	}
 }
-
-// Placeholder for authentication middleware
-func AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// TODO: Implement JWT or session-based authentication
-		c.Next()
-	}
-}
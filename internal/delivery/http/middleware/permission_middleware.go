@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission allows the request only if the authenticated caller holds
+// every given permission. Must be registered after AuthMiddleware.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", nil)
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !claims.HasPermission(permission) {
+				utils.ErrorResponse(c, http.StatusForbidden, "Forbidden", nil)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermissionOrSelf allows the request if the caller holds the permission
+// or if the user ID in the idParam path parameter is the caller's own ID.
+// Example: RequirePermissionOrSelf("users:update", "id") lets a user update
+// themselves while only holders of users:update may update others.
+func RequirePermissionOrSelf(permission, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", nil)
+			c.Abort()
+			return
+		}
+
+		if claims.HasPermission(permission) {
+			c.Next()
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param(idParam), 10, 64)
+		if err == nil && id == claims.UserID {
+			c.Next()
+			return
+		}
+
+		utils.ErrorResponse(c, http.StatusForbidden, "Forbidden", nil)
+		c.Abort()
+	}
+}
+
+// ForbidImpersonation rejects the request when it is made with an impersonation token.
+// Sensitive account operations such as changing the password or 2FA stay with the real user.
+// Must be registered after AuthMiddleware.
+func ForbidImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", nil)
+			c.Abort()
+			return
+		}
+
+		if claims.IsImpersonation() {
+			utils.ErrorResponse(c, http.StatusForbidden, "Not allowed while impersonating a user", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetClaims returns the claims stored by AuthMiddleware
+func GetClaims(c *gin.Context) (*auth.Claims, bool) {
+	value, exists := c.Get(CtxClaimsKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*auth.Claims)
+	return claims, ok && claims != nil
+}
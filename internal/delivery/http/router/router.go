@@ -4,6 +4,9 @@ import (
 	"go-gin-sqlx-template/config"
 	"go-gin-sqlx-template/internal/delivery/http/handler"
 	"go-gin-sqlx-template/internal/delivery/http/middleware"
+	"go-gin-sqlx-template/internal/integration/storage"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
 	"go-gin-sqlx-template/pkg/database"
 	"go-gin-sqlx-template/pkg/logger"
 	"go-gin-sqlx-template/pkg/utils"
@@ -15,28 +18,76 @@ import (
 )
 
 type Router struct {
-	engine      *gin.Engine
-	userHandler *handler.UserHandler
-	logger      *logger.Logger
-	db          *database.Database
-	redisClient *database.RedisClient
-	cfg         config.Config
+	engine               *gin.Engine
+	userHandler          *handler.UserHandler
+	authHandler          *handler.AuthHandler
+	roleHandler          *handler.RoleHandler
+	apiKeyHandler        *handler.APIKeyHandler
+	mfaHandler           *handler.MFAHandler
+	sessionHandler       *handler.SessionHandler
+	oidcHandler          *handler.OIDCHandler
+	importHandler        *handler.UserImportHandler
+	auditLogHandler      *handler.AuditLogHandler
+	avatarHandler        *handler.AvatarHandler
+	impersonationHandler *handler.ImpersonationHandler
+	organizationHandler  *handler.OrganizationHandler
+	jwtManager           *auth.JWTManager
+	apiKeyUsecase        usecase.APIKeyUsecase
+	sessionUsecase       usecase.SessionUsecase
+	organizationUsecase  usecase.OrganizationUsecase
+	logger               *logger.Logger
+	db                   *database.Database
+	redisClient          *database.RedisClient
+	fileStorage          storage.Storage
+	cfg                  config.Config
 }
 
 func NewRouter(
 	userHandler *handler.UserHandler,
+	authHandler *handler.AuthHandler,
+	roleHandler *handler.RoleHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	mfaHandler *handler.MFAHandler,
+	sessionHandler *handler.SessionHandler,
+	oidcHandler *handler.OIDCHandler,
+	importHandler *handler.UserImportHandler,
+	auditLogHandler *handler.AuditLogHandler,
+	avatarHandler *handler.AvatarHandler,
+	impersonationHandler *handler.ImpersonationHandler,
+	organizationHandler *handler.OrganizationHandler,
+	jwtManager *auth.JWTManager,
+	apiKeyUsecase usecase.APIKeyUsecase,
+	sessionUsecase usecase.SessionUsecase,
+	organizationUsecase usecase.OrganizationUsecase,
 	logger *logger.Logger,
 	db *database.Database,
 	redisClient *database.RedisClient,
+	fileStorage storage.Storage,
 	cfg config.Config,
 ) *Router {
 	return &Router{
-		engine:      gin.New(),
-		userHandler: userHandler,
-		logger:      logger,
-		db:          db,
-		redisClient: redisClient,
-		cfg:         cfg,
+		engine:               gin.New(),
+		userHandler:          userHandler,
+		authHandler:          authHandler,
+		roleHandler:          roleHandler,
+		apiKeyHandler:        apiKeyHandler,
+		mfaHandler:           mfaHandler,
+		sessionHandler:       sessionHandler,
+		oidcHandler:          oidcHandler,
+		importHandler:        importHandler,
+		auditLogHandler:      auditLogHandler,
+		avatarHandler:        avatarHandler,
+		impersonationHandler: impersonationHandler,
+		organizationHandler:  organizationHandler,
+		jwtManager:           jwtManager,
+		apiKeyUsecase:        apiKeyUsecase,
+		sessionUsecase:       sessionUsecase,
+		organizationUsecase:  organizationUsecase,
+		logger:               logger,
+		db:                   db,
+		redisClient:          redisClient,
+		fileStorage:          fileStorage,
+		cfg:                  cfg,
 	}
 }
 
@@ -45,23 +96,114 @@ func (r *Router) Setup() *gin.Engine {
 	r.engine.Use(otelgin.Middleware(r.cfg.ServiceName))
 
 	// Apply global middleware
+	r.engine.Use(middleware.RequestID())
+	r.engine.Use(middleware.ErrorFormat(r.cfg))
 	r.engine.Use(middleware.Recovery(r.logger))
 	r.engine.Use(middleware.RequestLogger(r.logger))
+	r.engine.Use(middleware.ErrorHandler(r.logger))
+
+	// Accepts either a bearer access token or an X-API-Key header
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager, r.apiKeyUsecase, r.sessionUsecase)
+	tenantMiddleware := middleware.TenantMiddleware(r.organizationUsecase)
 
 	// Health check endpoint
 	r.engine.GET("/health", r.healthCheck)
 
+	// Uploaded files of the local storage, other storages serve them on their own
+	if local, ok := r.fileStorage.(*storage.LocalStorage); ok {
+		r.engine.Static(storage.LocalURLPath, local.Dir())
+	}
+
 	// API v1 routes
 	v1 := r.engine.Group("/api/v1")
 	{
+		// Auth routes
+		authRoutes := v1.Group("/auth")
+		{
+			authRoutes.POST("/login", r.authHandler.Login)
+			authRoutes.POST("/refresh", r.authHandler.Refresh)
+			authRoutes.POST("/logout", r.authHandler.Logout)
+			authRoutes.POST("/password/forgot", r.authHandler.ForgotPassword)
+			authRoutes.POST("/password/reset", r.authHandler.ResetPassword)
+			authRoutes.GET("/verify", r.authHandler.VerifyEmail)
+			authRoutes.POST("/verify/resend", r.authHandler.ResendVerification)
+			authRoutes.POST("/mfa/verify", r.authHandler.VerifyMFA)
+			authRoutes.GET("/oidc/providers", r.oidcHandler.ListProviders)
+			authRoutes.GET("/oidc/:provider/login", r.oidcHandler.Login)
+			authRoutes.GET("/oidc/:provider/callback", r.oidcHandler.Callback)
+		}
+
+		// Current user routes
+		me := v1.Group("/me", authMiddleware)
+		{
+			// Impersonation tokens cannot change the credentials of the user
+			me.PUT("/password", middleware.ForbidImpersonation(), r.authHandler.ChangePassword)
+			me.POST("/2fa/enroll", middleware.ForbidImpersonation(), r.mfaHandler.Enroll)
+			me.POST("/2fa/confirm", middleware.ForbidImpersonation(), r.mfaHandler.Confirm)
+			me.GET("/sessions", r.sessionHandler.ListSessions)
+			me.DELETE("/sessions/:id", r.sessionHandler.RevokeSession)
+			me.DELETE("/impersonation", r.impersonationHandler.EndImpersonation)
+			me.GET("/organizations", r.organizationHandler.GetMyOrganizations)
+		}
+
 		// User routes
 		users := v1.Group("/users")
 		{
+			// Registration is public, everything else requires a valid access token
+			// and is scoped to the organization of the request
 			users.POST("", r.userHandler.CreateUser)
-			users.GET("", r.userHandler.GetAllUsers)
-			users.GET("/:id", middleware.CacheMiddleware(r.redisClient, 1*time.Minute, r.logger), r.userHandler.GetUserByID)
-			users.PUT("/:id", r.userHandler.UpdateUser)
-			users.DELETE("/:id", r.userHandler.DeleteUser)
+
+			protected := users.Group("", authMiddleware, tenantMiddleware)
+			protected.GET("", middleware.RequirePermission("users:read"), r.userHandler.GetAllUsers)
+			protected.GET("/export", middleware.RequirePermission("users:read"), r.userHandler.ExportUsers)
+			protected.POST("/batch", middleware.RequirePermission("users:batch"), r.userHandler.BatchUsers)
+			protected.POST("/import", middleware.RequirePermission("users:import"), r.importHandler.CreateImport)
+			protected.GET("/import/:id", middleware.RequirePermission("users:import"), r.importHandler.GetImport)
+			protected.GET("/:id", middleware.RequirePermissionOrSelf("users:read", "id"), middleware.CacheMiddleware(r.redisClient, 1*time.Minute, r.logger), r.userHandler.GetUserByID)
+			protected.PUT("/:id", middleware.RequirePermissionOrSelf("users:update", "id"), r.userHandler.UpdateUser)
+			protected.PATCH("/:id", middleware.RequirePermissionOrSelf("users:update", "id"), r.userHandler.PatchUser)
+			protected.DELETE("/:id", middleware.RequirePermission("users:delete"), r.userHandler.DeleteUser)
+			protected.POST("/:id/restore", middleware.RequirePermission("users:restore"), r.userHandler.RestoreUser)
+			protected.POST("/:id/unlock", middleware.RequirePermission("users:unlock"), r.authHandler.UnlockAccount)
+			protected.DELETE("/:id/2fa", middleware.RequirePermission("mfa:reset"), middleware.ForbidImpersonation(), r.mfaHandler.Reset)
+			protected.DELETE("/:id/sessions", middleware.RequirePermission("sessions:revoke"), r.sessionHandler.RevokeUserSessions)
+			protected.POST("/:id/avatar", middleware.RequirePermissionOrSelf("users:update", "id"), r.avatarHandler.UploadAvatar)
+			protected.POST("/:id/impersonate", middleware.RequirePermission("users:impersonate"), middleware.ForbidImpersonation(), r.impersonationHandler.StartImpersonation)
+
+			// User role assignment
+			protected.GET("/:id/roles", middleware.RequirePermissionOrSelf("roles:read", "id"), r.roleHandler.GetUserRoles)
+			protected.POST("/:id/roles", middleware.RequirePermission("roles:assign"), r.roleHandler.AssignRole)
+			protected.DELETE("/:id/roles/:role", middleware.RequirePermission("roles:assign"), r.roleHandler.RemoveRole)
+		}
+
+		// Role routes
+		roles := v1.Group("/roles", authMiddleware)
+		{
+			roles.GET("", middleware.RequirePermission("roles:read"), r.roleHandler.GetAllRoles)
+		}
+
+		// API key routes
+		apiKeys := v1.Group("/api-keys", authMiddleware, tenantMiddleware, middleware.RequirePermission("api_keys:manage"), middleware.ForbidImpersonation())
+		{
+			apiKeys.POST("", r.apiKeyHandler.CreateAPIKey)
+			apiKeys.GET("", r.apiKeyHandler.GetAllAPIKeys)
+			apiKeys.DELETE("/:id", r.apiKeyHandler.RevokeAPIKey)
+		}
+
+		// Organization routes
+		organizations := v1.Group("/organizations", authMiddleware, middleware.RequirePermission("organizations:manage"))
+		{
+			organizations.POST("", r.organizationHandler.CreateOrganization)
+			organizations.GET("", r.organizationHandler.GetAllOrganizations)
+			organizations.GET("/:id", r.organizationHandler.GetOrganization)
+			organizations.POST("/:id/members", r.organizationHandler.AddMember)
+			organizations.DELETE("/:id/members/:user_id", r.organizationHandler.RemoveMember)
+		}
+
+		// Audit log routes
+		auditLogs := v1.Group("/audit-logs", authMiddleware, tenantMiddleware)
+		{
+			auditLogs.GET("", middleware.RequirePermission("audit_logs:read"), r.auditLogHandler.GetAuditLogs)
 		}
 	}
 
@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type MailService struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func NewMailService(host, port, username, password, from string) *MailService {
+	return &MailService{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
+
+// SendMail sends a plain text email through the configured SMTP server
+func (s *MailService) SendMail(ctx context.Context, to, subject, body string) error {
+	tracer := otel.Tracer("integration/mail")
+	_, span := tracer.Start(ctx, "SMTP SendMail",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("mail.subject", subject),
+		attribute.String("smtp.host", s.Host),
+	)
+
+	var msg strings.Builder
+	msg.WriteString("From: " + s.From + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	addr := net.JoinHostPort(s.Host, s.Port)
+	if err := smtp.SendMail(addr, auth, s.From, []string{to}, []byte(msg.String())); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to send mail")
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
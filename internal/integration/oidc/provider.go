@@ -0,0 +1,304 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// jwksRefreshInterval is the minimum time between two JWKS fetches triggered by
+	// unknown key IDs, so tokens with made up key IDs cannot flood the provider
+	jwksRefreshInterval = time.Minute
+)
+
+var (
+	// ErrInvalidIDToken is returned when an ID token fails signature or claim validation
+	ErrInvalidIDToken = errors.New("invalid id token")
+	// ErrNonceMismatch is returned when the ID token was not issued for the login being completed
+	ErrNonceMismatch = errors.New("id token nonce mismatch")
+)
+
+// Discovery is the subset of the OpenID Provider Metadata used for the authorization code flow
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// IDTokenClaims are the ID token claims used to identify the user
+type IDTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// Provider runs the authorization code flow with PKCE against one identity provider.
+// The discovery document is loaded on first use and the signing keys are cached
+// until a token references an unknown key ID, at most once per jwksRefreshInterval.
+type Provider struct {
+	config config.OIDCProvider
+
+	mu        sync.Mutex
+	discovery *Discovery
+
+	keysMu        sync.Mutex
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+func NewProvider(cfg config.OIDCProvider) *Provider {
+	return &Provider{
+		config: cfg,
+	}
+}
+
+// Name returns the configured provider name
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL returns the URL to send the user to. The verifier must be kept
+// server-side and passed to Exchange, only its S256 challenge leaves the server.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	discovery, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	values := url.Values{}
+	values.Set("response_type", "code")
+	values.Set("client_id", p.config.ClientID)
+	values.Set("redirect_uri", p.config.RedirectURL)
+	values.Set("scope", strings.Join(scopes, " "))
+	values.Set("state", state)
+	values.Set("nonce", nonce)
+	values.Set("code_challenge", PKCEChallenge(verifier))
+	values.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + values.Encode(), nil
+}
+
+// Exchange redeems the authorization code and returns the verified ID token claims
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*IDTokenClaims, error) {
+	discovery, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("client_secret", p.config.ClientSecret)
+	form.Set("code_verifier", verifier)
+
+	resp, err := utils.SendRequest(ctx, utils.HttpRequestConfig{
+		Method: utils.MethodPost,
+		URL:    discovery.TokenEndpoint,
+		Headers: map[string]string{
+			utils.HeaderContentType: utils.ContentTypeForm,
+			utils.HeaderAccept:      utils.ContentTypeJson,
+		},
+		Body: form.Encode(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send token request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, string(resp.Body))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(resp.Body, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidIDToken)
+	}
+
+	return p.VerifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// VerifyIDToken checks the signature against the provider's JWKS, the issuer,
+// the audience, the expiry and the nonce of the login
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	discovery, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.key(ctx, discovery, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub claim", ErrInvalidIDToken)
+	}
+	if claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	return claims, nil
+}
+
+// Discover returns the provider metadata, fetching it on first use. The document is
+// fetched without holding the lock, so a provider that is slow or down does not block
+// other requests past their own context. Concurrent first uses may each fetch it,
+// the first stored document wins.
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	p.mu.Lock()
+	discovery := p.discovery
+	p.mu.Unlock()
+
+	if discovery != nil {
+		return discovery, nil
+	}
+
+	discovery, err := p.fetchDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery == nil {
+		p.discovery = discovery
+	}
+	return p.discovery, nil
+}
+
+// fetchDiscovery downloads and checks the discovery document of the issuer
+func (p *Provider) fetchDiscovery(ctx context.Context) (*Discovery, error) {
+	issuer := strings.TrimSuffix(p.config.IssuerURL, "/")
+	resp, err := utils.SendRequest(ctx, utils.HttpRequestConfig{
+		Method: utils.MethodGet,
+		URL:    issuer + discoveryPath,
+		Headers: map[string]string{
+			utils.HeaderAccept: utils.ContentTypeJson,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery endpoint returned status %d", resp.StatusCode)
+	}
+
+	var discovery Discovery
+	if err := json.Unmarshal(resp.Body, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+
+	// The issuer in the document must be the one we were configured with, or
+	// tokens from another tenant of the same provider could be accepted
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovery issuer %q does not match configured issuer %q", discovery.Issuer, p.config.IssuerURL)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("discovery document is missing required endpoints")
+	}
+
+	return &discovery, nil
+}
+
+// key returns the verification key with the given ID, refreshing the JWKS if the
+// key is unknown so provider key rotation is picked up. The JWKS is fetched without
+// holding the lock, so a slow provider does not block tokens signed with known keys.
+func (p *Provider) key(ctx context.Context, discovery *Discovery, kid string) (any, error) {
+	p.keysMu.Lock()
+	keys := p.keys
+	key, ok := lookupKey(keys, kid)
+	// Reserve the refresh before fetching so concurrent unknown key IDs share it
+	refresh := !ok && (keys == nil || time.Since(p.keysFetchedAt) >= jwksRefreshInterval)
+	if refresh {
+		p.keysFetchedAt = time.Now()
+	}
+	p.keysMu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if !refresh {
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+
+	keys, err := fetchJWKS(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p.keysMu.Lock()
+	p.keys = keys
+	p.keysMu.Unlock()
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// lookupKey returns the key with the given ID.
+// Providers with a single key may omit the kid.
+func lookupKey(keys map[string]any, kid string) (any, bool) {
+	if key, ok := keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// PKCEChallenge returns the S256 code challenge of the verifier (RFC 7636)
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,327 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-gin-sqlx-template/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "client-1"
+	testCode     = "auth-code"
+	testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+// stubProvider is an identity provider serving the discovery document, the JWKS
+// and a token endpoint that only redeems testCode with testVerifier
+type stubProvider struct {
+	*httptest.Server
+	jwksFetches atomic.Int32
+
+	mu      sync.Mutex
+	keyID   string
+	key     *rsa.PrivateKey
+	idToken string
+}
+
+func newStubProvider(t *testing.T) *stubProvider {
+	t.Helper()
+
+	s := &stubProvider{}
+	s.rotateKey(t, "key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Discovery{
+			Issuer:                s.URL,
+			AuthorizationEndpoint: s.URL + "/authorize",
+			TokenEndpoint:         s.URL + "/token",
+			JWKSURI:               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		s.jwksFetches.Add(1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": s.keyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("code") != testCode ||
+			r.PostFormValue("code_verifier") != testVerifier || r.PostFormValue("client_id") != testClientID {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]string{"id_token": s.idToken})
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *stubProvider) rotateKey(t *testing.T, keyID string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyID = keyID
+	s.key = key
+}
+
+// sign returns an ID token for the client signed with the current key
+func (s *stubProvider) sign(t *testing.T, nonce string, modify func(claims *IDTokenClaims)) string {
+	t.Helper()
+
+	now := time.Now()
+	claims := &IDTokenClaims{
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Nonce:         nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.URL,
+			Subject:   "subject-1",
+			Audience:  jwt.ClaimStrings{testClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+	if modify != nil {
+		modify(claims)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyID
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (s *stubProvider) provider() *Provider {
+	return NewProvider(config.OIDCProvider{
+		Name:        "stub",
+		IssuerURL:   s.URL + "/",
+		ClientID:    testClientID,
+		RedirectURL: "https://app.example.com/callback",
+	})
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// RFC 7636 Appendix B
+	if got, want := PKCEChallenge(testVerifier), "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("PKCEChallenge = %s, want %s", got, want)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	stub := newStubProvider(t)
+
+	discovery, err := stub.provider().Discover(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if discovery.TokenEndpoint != stub.URL+"/token" || discovery.JWKSURI != stub.URL+"/jwks" {
+		t.Errorf("discovery = %+v", discovery)
+	}
+
+	other := NewProvider(config.OIDCProvider{IssuerURL: stub.URL + "/tenant-2"})
+	if _, err := other.Discover(context.Background()); err == nil {
+		t.Error("Discover with another issuer succeeded, want an error")
+	}
+}
+
+func TestDiscoverDoesNotWaitForAnotherFetch(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	provider := NewProvider(config.OIDCProvider{IssuerURL: server.URL})
+
+	// A first request keeps waiting for the provider
+	go provider.Discover(context.Background())
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := provider.Discover(ctx); err == nil {
+		t.Fatal("Discover succeeded, want an error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Discover returned after %s, want it to give up with its own context", elapsed)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	stub := newStubProvider(t)
+
+	rawURL, err := stub.provider().AuthCodeURL(context.Background(), "state-1", "nonce-1", testVerifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          "https://app.example.com/callback",
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		"code_challenge_method": "S256",
+	}
+	for name, value := range want {
+		if got := query.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	if query.Has("code_verifier") {
+		t.Error("the code verifier must not leave the server")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	stub := newStubProvider(t)
+	provider := stub.provider()
+	ctx := context.Background()
+	idToken := stub.sign(t, "nonce-1", nil)
+	stub.mu.Lock()
+	stub.idToken = idToken
+	stub.mu.Unlock()
+
+	claims, err := provider.Exchange(ctx, testCode, testVerifier, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "subject-1" || claims.Email != "jane@example.com" || !claims.EmailVerified {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	if _, err := provider.Exchange(ctx, testCode, testVerifier, "nonce-2"); !errors.Is(err, ErrNonceMismatch) {
+		t.Errorf("Exchange with another nonce = %v, want ErrNonceMismatch", err)
+	}
+
+	if _, err := provider.Exchange(ctx, testCode, "other-verifier", "nonce-1"); err == nil {
+		t.Error("Exchange with another verifier succeeded, want an error")
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	stub := newStubProvider(t)
+	provider := stub.provider()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		modify func(claims *IDTokenClaims)
+	}{
+		{"other audience", func(claims *IDTokenClaims) { claims.Audience = jwt.ClaimStrings{"client-2"} }},
+		{"other issuer", func(claims *IDTokenClaims) { claims.Issuer = "https://evil.example.com" }},
+		{"expired", func(claims *IDTokenClaims) { claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour)) }},
+		{"missing subject", func(claims *IDTokenClaims) { claims.Subject = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.VerifyIDToken(ctx, stub.sign(t, "nonce-1", tt.modify), "nonce-1")
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("VerifyIDToken = %v, want ErrInvalidIDToken", err)
+			}
+		})
+	}
+
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "subject-1"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.VerifyIDToken(ctx, forged, ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("VerifyIDToken with an HMAC token = %v, want ErrInvalidIDToken", err)
+	}
+}
+
+func TestVerifyIDTokenKeyRotation(t *testing.T) {
+	stub := newStubProvider(t)
+	provider := stub.provider()
+	ctx := context.Background()
+
+	if _, err := provider.VerifyIDToken(ctx, stub.sign(t, "nonce-1", nil), "nonce-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.VerifyIDToken(ctx, stub.sign(t, "nonce-1", nil), "nonce-1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := stub.jwksFetches.Load(); got != 1 {
+		t.Fatalf("jwks fetched %d times, want the cached keys to be used", got)
+	}
+
+	// A rotated key is picked up once the refresh interval has passed
+	stub.rotateKey(t, "key-2")
+	rotated := stub.sign(t, "nonce-1", nil)
+	if _, err := provider.VerifyIDToken(ctx, rotated, "nonce-1"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("VerifyIDToken within the refresh interval = %v, want ErrInvalidIDToken", err)
+	}
+	if got := stub.jwksFetches.Load(); got != 1 {
+		t.Fatalf("jwks fetched %d times, want no refresh within the interval", got)
+	}
+
+	provider.keysMu.Lock()
+	provider.keysFetchedAt = time.Now().Add(-jwksRefreshInterval)
+	provider.keysMu.Unlock()
+
+	if _, err := provider.VerifyIDToken(ctx, rotated, "nonce-1"); err != nil {
+		t.Fatalf("VerifyIDToken after the refresh interval = %v", err)
+	}
+	if got := stub.jwksFetches.Load(); got != 2 {
+		t.Fatalf("jwks fetched %d times, want 2", got)
+	}
+
+	// Made up key IDs do not trigger another fetch
+	for _, kid := range []string{"key-3", "key-4", "key-5"} {
+		stub.rotateKey(t, kid)
+		if _, err := provider.VerifyIDToken(ctx, stub.sign(t, "nonce-1", nil), "nonce-1"); err == nil {
+			t.Fatalf("VerifyIDToken with unknown key %s succeeded", kid)
+		}
+	}
+	if got := stub.jwksFetches.Load(); got != 2 {
+		t.Errorf("jwks fetched %d times, want 2", got)
+	}
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(dir, "/uploads/")
+	ctx := context.Background()
+	key := "avatars/7/a.png"
+
+	if err := s.Put(ctx, key, []byte("image"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "avatars", "7", "a.png")); err != nil || string(data) != "image" {
+		t.Fatalf("file = %q, %v", data, err)
+	}
+
+	// Replacing leaves no temporary files behind
+	if err := s.Put(ctx, key, []byte("new image"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "avatars", "7"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("directory has %d entries, %v", len(entries), err)
+	}
+
+	data, err := s.Get(ctx, key)
+	if err != nil || string(data) != "new image" {
+		t.Fatalf("Get = %q, %v", data, err)
+	}
+
+	if got, want := s.URL(key), "/uploads/avatars/7/a.png"; got != want {
+		t.Errorf("URL = %s, want %s", got, want)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete of a missing file: %v", err)
+	}
+}
+
+func TestLocalStorageInvalidKey(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), "/uploads")
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "avatars/../../secret", "avatars//a.png"} {
+		if err := s.Put(context.Background(), key, []byte("x"), "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
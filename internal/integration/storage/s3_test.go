@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// TestS3SignatureV4 checks the signature against one computed independently
+// from the Signature Version 4 specification
+func TestS3SignatureV4(t *testing.T) {
+	s, err := NewS3Storage(S3Config{
+		Endpoint:  "http://localhost:9000",
+		Bucket:    "avatars",
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte("hello")
+	req, err := http.NewRequest(http.MethodPut, s.bucketURL()+"/"+escapeS3Path("avatars/7/a b.png"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.sign(req, body, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	if got, want := req.URL.EscapedPath(), "/avatars/avatars/7/a%20b.png"; got != want {
+		t.Errorf("path = %s, want %s", got, want)
+	}
+	if got, want := req.Header.Get("X-Amz-Content-Sha256"), "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; got != want {
+		t.Errorf("payload hash = %s, want %s", got, want)
+	}
+	if got, want := req.Header.Get("X-Amz-Date"), "20240102T030405Z"; got != want {
+		t.Errorf("date = %s, want %s", got, want)
+	}
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/s3/aws4_request, " +
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date, " +
+		"Signature=6967abfffb8d5069a46ca4334558c5d1e218cc35ceed6754d6c8af8c2227ce6b"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("authorization = %s, want %s", got, want)
+	}
+}
+
+// fakeS3 is a stand-in bucket that keeps objects in memory and rejects requests
+// without Signature Version 4 headers matching the body
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) ||
+		!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+testAccessKey+"/") {
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = string(body)
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, object)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{bucket: "avatars", objects: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s, err := NewS3Storage(S3Config{
+		Endpoint:  server.URL,
+		Bucket:    "avatars",
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	key := "avatars/7/a b.png"
+
+	if err := s.Put(ctx, key, []byte("image"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if fake.objects[key] != "image" {
+		t.Fatalf("stored %q, want %q", fake.objects[key], "image")
+	}
+
+	data, err := s.Get(ctx, key)
+	if err != nil || string(data) != "image" {
+		t.Fatalf("Get = %q, %v", data, err)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+	}
+
+	if _, err := s.Get(ctx, "../secret"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Get with invalid key = %v, want ErrInvalidKey", err)
+	}
+
+	if got, want := s.URL(key), server.URL+"/avatars/avatars/7/a%20b.png"; got != want {
+		t.Errorf("URL = %s, want %s", got, want)
+	}
+}
+
+func TestS3StorageError(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{bucket: "avatars", objects: map[string]string{}})
+	defer server.Close()
+
+	s, err := NewS3Storage(S3Config{
+		Endpoint:  server.URL,
+		Bucket:    "avatars",
+		AccessKey: "wrong",
+		SecretKey: testSecretKey,
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Put(context.Background(), "avatars/7/a.png", []byte("image"), "image/png")
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Fatalf("Put = %v, want the 403 of the service", err)
+	}
+}
+
+func TestS3StorageVirtualHostedURL(t *testing.T) {
+	s, err := NewS3Storage(S3Config{
+		Endpoint: "https://s3.eu-west-1.amazonaws.com",
+		Bucket:   "avatars",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := s.URL("avatars/7/a.png"), "https://avatars.s3.eu-west-1.amazonaws.com/avatars/7/a.png"; got != want {
+		t.Errorf("URL = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"go-gin-sqlx-template/config"
+)
+
+// Storage drivers selectable with STORAGE_DRIVER
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// LocalURLPath is where the API serves the files of the local driver
+const LocalURLPath = "/uploads"
+
+var (
+	// ErrNotFound is returned when no object is stored under the key
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for keys that are empty, absolute or escape the storage root
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Storage stores files under slash separated keys such as avatars/7/3f9a.png
+type Storage interface {
+	// Put stores the object under key, replacing any existing one
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the content of the object, ErrNotFound if it does not exist
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL the object is served at
+	URL(key string) string
+}
+
+// New creates the storage configured with STORAGE_DRIVER, local by default
+func New(cfg config.Config) (Storage, error) {
+	switch cfg.StorageDriver {
+	case "", DriverLocal:
+		publicURL := cfg.StoragePublicURL
+		if publicURL == "" {
+			publicURL = LocalURLPath
+		}
+		return NewLocalStorage(cfg.StorageLocalDir, publicURL), nil
+	case DriverS3:
+		return NewS3Storage(S3Config{
+			Endpoint:  cfg.StorageS3Endpoint,
+			Region:    cfg.StorageS3Region,
+			Bucket:    cfg.StorageS3Bucket,
+			AccessKey: cfg.StorageS3AccessKey,
+			SecretKey: cfg.StorageS3SecretKey,
+			PathStyle: cfg.StorageS3PathStyle,
+			PublicURL: cfg.StoragePublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
+
+// validateKey rejects keys that could address files outside the storage root
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+// joinURL appends the key to a base URL
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
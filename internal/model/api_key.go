@@ -0,0 +1,99 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKey represents a credential for service-to-service callers
+type APIKey struct {
+	ID        int64          `db:"id"`
+	Name      string         `db:"name"`
+	Prefix    string         `db:"prefix"`
+	KeyHash   string         `db:"key_hash"`
+	Scopes    pq.StringArray `db:"scopes"`
+	CreatedBy *int64         `db:"created_by"`
+	// OrganizationID is the organization the key acts in, nil for keys managing every organization
+	OrganizationID *int64     `db:"organization_id"`
+	ExpiresAt      *time.Time `db:"expires_at"`
+	LastUsedAt     *time.Time `db:"last_used_at"`
+	RevokedAt      *time.Time `db:"revoked_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+// IsActive reports whether the key may still be used at the given time
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return false
+	}
+	return true
+}
+
+// CreateAPIKeyRequest represents the payload for creating an API key
+// swagger:model CreateAPIKeyRequest
+type CreateAPIKeyRequest struct {
+	// A descriptive name, e.g. the calling service
+	// required: true
+	Name string `json:"name" binding:"required,min=3,max=100" example:"billing-cron"`
+	// Permissions granted to the key
+	// required: true
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,required" example:"users:read"`
+	// Optional expiration time
+	ExpiresAt *time.Time `json:"expires_at" example:"2026-12-31T00:00:00Z"`
+}
+
+// APIKeyResponse represents an API key without its secret
+// swagger:model APIKeyResponse
+type APIKeyResponse struct {
+	// The API key ID
+	ID int64 `json:"id" example:"1"`
+	// The API key name
+	Name string `json:"name" example:"billing-cron"`
+	// Public prefix used to identify the key
+	Prefix string `json:"prefix" example:"sk_1a2b3c4d5e6f"`
+	// Permissions granted to the key
+	Scopes []string `json:"scopes" example:"users:read"`
+	// The user who created the key
+	CreatedBy *int64 `json:"created_by,omitempty" example:"1"`
+	// The organization the key acts in
+	OrganizationID *int64 `json:"organization_id,omitempty" example:"1"`
+	// Expiration time
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2026-12-31T00:00:00Z"`
+	// Last time the key was used
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-12-06T17:16:43+07:00"`
+	// Revocation time
+	RevokedAt *time.Time `json:"revoked_at,omitempty" example:"2025-12-06T17:16:43+07:00"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at" example:"2025-12-06T17:16:43+07:00"`
+}
+
+// CreateAPIKeyResponse is returned once on creation and includes the plain key
+// swagger:model CreateAPIKeyResponse
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	// The API key, it cannot be retrieved again
+	Key string `json:"key" example:"sk_1a2b3c4d5e6f.3q2-7wX9..."`
+}
+
+func (k *APIKey) ToResponse() APIKeyResponse {
+	scopes := []string(k.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return APIKeyResponse{
+		ID:             k.ID,
+		Name:           k.Name,
+		Prefix:         k.Prefix,
+		Scopes:         scopes,
+		CreatedBy:      k.CreatedBy,
+		OrganizationID: k.OrganizationID,
+		ExpiresAt:      k.ExpiresAt,
+		LastUsedAt:     k.LastUsedAt,
+		RevokedAt:      k.RevokedAt,
+		CreatedAt:      k.CreatedAt,
+	}
+}
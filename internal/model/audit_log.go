@@ -0,0 +1,144 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Audit log entity types
+const (
+	AuditEntityUser         = "user"
+	AuditEntityOrganization = "organization"
+)
+
+// Audit log actions
+const (
+	AuditActionUserCreated         = "user.created"
+	AuditActionUserUpdated         = "user.updated"
+	AuditActionUserDeleted         = "user.deleted"
+	AuditActionUserRestored        = "user.restored"
+	AuditActionUserImported        = "user.imported"
+	AuditActionUserPasswordChanged = "user.password_changed"
+	AuditActionUserPasswordReset   = "user.password_reset"
+	AuditActionUserEmailVerified   = "user.email_verified"
+	AuditActionUserRoleAssigned    = "user.role_assigned"
+	AuditActionUserRoleRemoved     = "user.role_removed"
+	AuditActionUserAvatarUpdated   = "user.avatar_updated"
+	AuditActionUserMFAEnrolled     = "user.mfa_enrolled"
+	AuditActionUserMFAEnabled      = "user.mfa_enabled"
+	AuditActionUserMFAReset        = "user.mfa_reset"
+	AuditActionUserSessionRevoked  = "user.session_revoked"
+	AuditActionUserSessionsRevoked = "user.sessions_revoked"
+	AuditActionUserUnlocked        = "user.unlocked"
+	AuditActionUserPurged          = "user.purged"
+
+	AuditActionUserImpersonationStarted = "user.impersonation_started"
+	AuditActionUserImpersonationEnded   = "user.impersonation_ended"
+
+	AuditActionOrganizationCreated       = "organization.created"
+	AuditActionOrganizationMemberAdded   = "organization.member_added"
+	AuditActionOrganizationMemberRemoved = "organization.member_removed"
+)
+
+// AuditLog records who changed what, written in the transaction of the change
+type AuditLog struct {
+	ID int64 `db:"id"`
+	// ActorUserID is the user who made the change, nil for API keys and the system
+	ActorUserID   *int64 `db:"actor_user_id"`
+	ActorAPIKeyID *int64 `db:"actor_api_key_id"`
+	// ImpersonatorUserID is the admin who made the change while impersonating ActorUserID
+	ImpersonatorUserID *int64 `db:"impersonator_user_id"`
+	// OrganizationID is the organization the change was made in, nil outside of one
+	OrganizationID *int64       `db:"organization_id"`
+	Action         string       `db:"action"`
+	EntityType     string       `db:"entity_type"`
+	EntityID       int64        `db:"entity_id"`
+	Changes        AuditChanges `db:"changes"`
+	RequestID      *string      `db:"request_id"`
+	IPAddress      *string      `db:"ip_address"`
+	CreatedAt      time.Time    `db:"created_at"`
+}
+
+// AuditChange holds the value of a field before and after the change
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditChanges maps the changed fields to their values, it is stored as a JSONB column
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c)
+}
+
+func (c *AuditChanges) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into AuditChanges", src)
+	}
+}
+
+// AuditLogResponse represents an audit log entry
+// swagger:model AuditLogResponse
+type AuditLogResponse struct {
+	// The audit log ID
+	ID int64 `json:"id" example:"1"`
+	// The user who made the change
+	ActorUserID *int64 `json:"actor_user_id,omitempty" example:"1"`
+	// The API key that made the change
+	ActorAPIKeyID *int64 `json:"actor_api_key_id,omitempty" example:"2"`
+	// The admin who made the change while impersonating the actor user
+	ImpersonatorUserID *int64 `json:"impersonator_user_id,omitempty" example:"1"`
+	// The organization the change was made in
+	OrganizationID *int64 `json:"organization_id,omitempty" example:"1"`
+	// What happened, e.g. user.updated
+	Action string `json:"action" example:"user.updated"`
+	// The kind of entity changed
+	EntityType string `json:"entity_type" example:"user"`
+	// The ID of the entity changed
+	EntityID int64 `json:"entity_id" example:"7"`
+	// Changed fields with their values before and after
+	Changes AuditChanges `json:"changes" swaggertype:"object"`
+	// The X-Request-ID of the request that made the change
+	RequestID *string `json:"request_id,omitempty" example:"4f1c2a9be07d4c35a1b0e6d2c8f3a7e1"`
+	// The client IP of the request
+	IPAddress *string `json:"ip_address,omitempty" example:"203.0.113.7"`
+	// Time of the change
+	CreatedAt time.Time `json:"created_at" example:"2025-12-06T17:16:43+07:00"`
+}
+
+// ToResponse converts AuditLog to AuditLogResponse
+func (l *AuditLog) ToResponse() AuditLogResponse {
+	changes := l.Changes
+	if changes == nil {
+		changes = AuditChanges{}
+	}
+
+	return AuditLogResponse{
+		ID:                 l.ID,
+		ActorUserID:        l.ActorUserID,
+		ActorAPIKeyID:      l.ActorAPIKeyID,
+		ImpersonatorUserID: l.ImpersonatorUserID,
+		OrganizationID:     l.OrganizationID,
+		Action:             l.Action,
+		EntityType:         l.EntityType,
+		EntityID:           l.EntityID,
+		Changes:            changes,
+		RequestID:          l.RequestID,
+		IPAddress:          l.IPAddress,
+		CreatedAt:          l.CreatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// LoginRequest represents the payload for logging in
+// swagger:model LoginRequest
+type LoginRequest struct {
+	// The email address
+	// required: true
+	Email string `json:"email" binding:"required,email" example:"user@gmail.com"`
+	// The password
+	// required: true
+	Password string `json:"password" binding:"required" example:"password"`
+}
+
+// TokenResponse represents the issued access token
+// swagger:model TokenResponse
+type TokenResponse struct {
+	// The signed access token
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// The token type, always Bearer
+	TokenType string `json:"token_type" example:"Bearer"`
+	// Lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in" example:"900"`
+	// Expiration time of the access token
+	ExpiresAt time.Time `json:"expires_at" example:"2025-12-06T17:31:43+07:00"`
+	// Opaque refresh token, exchange it at /auth/refresh for a new token pair
+	RefreshToken string `json:"refresh_token,omitempty" example:"3q2-7wX9..."`
+	// Expiration time of the refresh token
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty" example:"2025-12-13T17:16:43+07:00"`
+}
+
+// ClientInfo describes the client making an authentication request
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
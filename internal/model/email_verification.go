@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+)
+
+// EmailVerificationToken represents a single-use email verification token.
+// Only the SHA-256 hash of the token is stored.
+type EmailVerificationToken struct {
+	ID        int64      `db:"id"`
+	UserID    int64      `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// ResendVerificationRequest represents the payload for resending the verification email
+// swagger:model ResendVerificationRequest
+type ResendVerificationRequest struct {
+	// The email address of the account
+	// required: true
+	Email string `json:"email" binding:"required,email" example:"user@gmail.com"`
+}
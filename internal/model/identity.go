@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+)
+
+// UserIdentity links a user to an account at an external OpenID Connect provider.
+// The provider's subject identifier is stable, unlike the email address.
+type UserIdentity struct {
+	ID       int64  `db:"id"`
+	UserID   int64  `db:"user_id"`
+	Provider string `db:"provider"`
+	Subject  string `db:"subject"`
+	// Email reported by the provider when the identity was linked
+	Email       string     `db:"email"`
+	LastLoginAt *time.Time `db:"last_login_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// OIDCState holds what is needed to complete an authorization code flow.
+// Only the SHA-256 hash of the state parameter is stored.
+type OIDCState struct {
+	StateHash    string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// OIDCProviderResponse represents a configured external identity provider
+// swagger:model OIDCProviderResponse
+type OIDCProviderResponse struct {
+	// Provider name used in the login URL
+	Name string `json:"name" example:"corp"`
+	// URL that starts the login flow
+	LoginURL string `json:"login_url" example:"/api/v1/auth/oidc/corp/login"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// ImpersonationResponse represents an issued impersonation token.
+// It has no refresh token, start a new impersonation once it expires.
+// swagger:model ImpersonationResponse
+type ImpersonationResponse struct {
+	TokenResponse
+	// The admin acting as the user
+	ImpersonatorID int64 `json:"impersonator_id" example:"1"`
+	// The impersonated user
+	User UserResponse `json:"user"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+)
+
+// UserMFA represents the TOTP two-factor authentication of a user.
+// The secret is only active once the user confirmed it with a valid code.
+type UserMFA struct {
+	UserID int64 `db:"user_id"`
+	// Base32 encoded TOTP secret, stored as is because it is needed to compute codes
+	Secret      string     `db:"secret"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	// The last TOTP time step accepted, codes from this step or earlier are rejected
+	LastUsedStep int64     `db:"last_used_step"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// IsEnabled reports whether the user confirmed the enrollment
+func (m *UserMFA) IsEnabled() bool {
+	return m.ConfirmedAt != nil
+}
+
+// MFAChallenge represents the pending second step of a login.
+// Only the SHA-256 hash of the challenge token is stored.
+type MFAChallenge struct {
+	TokenHash string
+	UserID    int64
+	// Number of wrong codes submitted for this challenge
+	Attempts  int64
+	ExpiresAt time.Time
+}
+
+// EnrollMFAResponse represents a new, not yet confirmed TOTP secret
+// swagger:model EnrollMFAResponse
+type EnrollMFAResponse struct {
+	// Base32 encoded secret for manual entry in an authenticator app
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// otpauth URI to render as a QR code
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/go-gin-sqlx-template:user@gmail.com?secret=JBSWY3DPEHPK3PXP&issuer=go-gin-sqlx-template"`
+}
+
+// ConfirmMFARequest represents the payload for confirming a TOTP enrollment
+// swagger:model ConfirmMFARequest
+type ConfirmMFARequest struct {
+	// The current code from the authenticator app
+	// required: true
+	Code string `json:"code" binding:"required,len=6,numeric" example:"123456"`
+}
+
+// ConfirmMFAResponse represents the recovery codes issued when 2FA is enabled
+// swagger:model ConfirmMFAResponse
+type ConfirmMFAResponse struct {
+	// Single-use recovery codes, only returned once
+	RecoveryCodes []string `json:"recovery_codes" example:"abcd-efgh,ijkl-mnop"`
+}
+
+// VerifyMFARequest represents the payload for the second step of a login
+// swagger:model VerifyMFARequest
+type VerifyMFARequest struct {
+	// The challenge token returned by /auth/login
+	// required: true
+	MFAToken string `json:"mfa_token" binding:"required" example:"3q2-7wX9..."`
+	// A code from the authenticator app or a recovery code
+	// required: true
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// LoginResponse represents the result of the first login step.
+// Either the token pair is returned, or MFARequired is set together with a
+// challenge token to exchange at /auth/mfa/verify.
+// swagger:model LoginResponse
+type LoginResponse struct {
+	*TokenResponse
+	// Set when the account has two-factor authentication enabled
+	MFARequired bool `json:"mfa_required,omitempty" example:"false"`
+	// Short-lived challenge token for /auth/mfa/verify
+	MFAToken string `json:"mfa_token,omitempty" example:"3q2-7wX9..."`
+	// Expiration time of the challenge token
+	MFAExpiresAt *time.Time `json:"mfa_expires_at,omitempty" example:"2025-12-06T17:21:43+07:00"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+)
+
+// Organization is a tenant. Users are global accounts that are members of one or more organizations.
+type Organization struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CreateOrganizationRequest represents the payload for creating an organization
+// swagger:model CreateOrganizationRequest
+type CreateOrganizationRequest struct {
+	// The organization name
+	// required: true
+	Name string `json:"name" binding:"required,min=2,max=255" example:"Acme Inc."`
+}
+
+// AddOrganizationMemberRequest represents the payload for adding a user to an organization
+// swagger:model AddOrganizationMemberRequest
+type AddOrganizationMemberRequest struct {
+	// The user to add
+	// required: true
+	UserID int64 `json:"user_id" binding:"required,min=1" example:"7"`
+}
+
+// OrganizationResponse represents an organization
+// swagger:model OrganizationResponse
+type OrganizationResponse struct {
+	// The organization ID
+	ID int64 `json:"id" example:"1"`
+	// The organization name
+	Name string `json:"name" example:"Acme Inc."`
+	// Creation time
+	CreatedAt time.Time `json:"created_at" example:"2025-12-06T17:16:43+07:00"`
+	// Last update time
+	UpdatedAt time.Time `json:"updated_at" example:"2025-12-06T17:16:43+07:00"`
+}
+
+func (o *Organization) ToResponse() OrganizationResponse {
+	return OrganizationResponse{
+		ID:        o.ID,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+)
+
+// PasswordResetToken represents a single-use password reset token.
+// Only the SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID        int64      `db:"id"`
+	UserID    int64      `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// ForgotPasswordRequest represents the payload for requesting a password reset
+// swagger:model ForgotPasswordRequest
+type ForgotPasswordRequest struct {
+	// The email address of the account
+	// required: true
+	Email string `json:"email" binding:"required,email" example:"user@gmail.com"`
+}
+
+// ResetPasswordRequest represents the payload for resetting a password
+// swagger:model ResetPasswordRequest
+type ResetPasswordRequest struct {
+	// The token received by email
+	// required: true
+	Token string `json:"token" binding:"required" example:"3q2-7wX9..."`
+	// The new password, with upper and lower case letters and a digit
+	// required: true
+	// min length: 8
+	Password string `json:"password" binding:"required,max_bytes=72,strong_password" example:"NewPassw0rd"`
+}
+
+// ChangePasswordRequest represents the payload for changing the password of the current user
+// swagger:model ChangePasswordRequest
+type ChangePasswordRequest struct {
+	// The current password
+	// required: true
+	CurrentPassword string `json:"current_password" binding:"required" example:"password"`
+	// The new password, must differ from the current one and have upper and lower case letters and a digit
+	// required: true
+	// min length: 8
+	NewPassword string `json:"new_password" binding:"required,max_bytes=72,strong_password,nefield=CurrentPassword" example:"NewPassw0rd"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+)
+
+// Role represents a named set of permissions
+// swagger:model Role
+type Role struct {
+	// The ID of the role
+	ID int64 `db:"id" json:"id"`
+	// The unique name of the role
+	Name string `db:"name" json:"name"`
+	// Human readable description
+	Description string `db:"description" json:"description"`
+	// Creation time
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// Last update time
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Permission represents a single action that can be granted, e.g. "users:delete"
+// swagger:model Permission
+type Permission struct {
+	// The ID of the permission
+	ID int64 `db:"id" json:"id"`
+	// The unique name of the permission
+	Name string `db:"name" json:"name"`
+	// Human readable description
+	Description string `db:"description" json:"description"`
+	// Creation time
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// AssignRoleRequest represents the payload for assigning a role to a user
+// swagger:model AssignRoleRequest
+type AssignRoleRequest struct {
+	// The role name
+	// required: true
+	Role string `json:"role" binding:"required" example:"admin"`
+}
+
+// RoleResponse represents a role and the permissions it grants
+// swagger:model RoleResponse
+type RoleResponse struct {
+	// The role ID
+	ID int64 `json:"id" example:"1"`
+	// The role name
+	Name string `json:"name" example:"admin"`
+	// The role description
+	Description string `json:"description" example:"Full access to every resource"`
+	// Permissions granted by the role
+	Permissions []string `json:"permissions" example:"users:read,users:delete"`
+}
+
+func (r *Role) ToResponse(permissions []string) RoleResponse {
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return RoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+	}
+}
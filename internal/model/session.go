@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+)
+
+// RefreshToken represents a stored opaque refresh token.
+// Tokens issued from the same login share a FamilyID, so reuse of an
+// already-rotated token can revoke every token descended from that login.
+type RefreshToken struct {
+	// SHA-256 hash of the opaque token, the raw token is never stored
+	TokenHash string
+	// The owner of the token
+	UserID int64
+	// Identifier shared by all tokens rotated from the same login
+	FamilyID string
+	// Creation time
+	CreatedAt time.Time
+	// Expiration time
+	ExpiresAt time.Time
+	// Set once the token has been exchanged for a new one
+	RotatedAt *time.Time
+}
+
+// IsRotated reports whether the token has already been exchanged
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+// RefreshTokenRequest represents the payload for refreshing or revoking a session
+// swagger:model RefreshTokenRequest
+type RefreshTokenRequest struct {
+	// The refresh token
+	// required: true
+	RefreshToken string `json:"refresh_token" binding:"required" example:"3q2-7wX9..."`
+}
+
+// Session represents a signed-in device. It corresponds to one refresh token
+// family, so its ID is the FamilyID shared by every token rotated from the login.
+type Session struct {
+	ID        string
+	UserID    int64
+	UserAgent string
+	IPAddress string
+	CreatedAt time.Time
+	// Last time an access or refresh token of the session was used
+	LastSeenAt time.Time
+	// Expiration time of the newest refresh token of the session
+	ExpiresAt time.Time
+}
+
+// SessionResponse represents a signed-in device of the current user
+// swagger:model SessionResponse
+type SessionResponse struct {
+	// The session ID
+	ID string `json:"id" example:"k3J9xQ2..."`
+	// The user agent of the client that logged in
+	UserAgent string `json:"user_agent" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7)"`
+	// The IP address of the client that logged in
+	IPAddress string `json:"ip_address" example:"203.0.113.7"`
+	// Login time
+	CreatedAt time.Time `json:"created_at" example:"2025-12-06T17:16:43+07:00"`
+	// Last activity time
+	LastSeenAt time.Time `json:"last_seen_at" example:"2025-12-06T18:02:11+07:00"`
+	// The session ends at this time unless it is refreshed
+	ExpiresAt time.Time `json:"expires_at" example:"2025-12-13T17:16:43+07:00"`
+	// Whether this is the session making the request
+	Current bool `json:"current" example:"true"`
+}
+
+// ToResponse converts Session to SessionResponse
+func (s *Session) ToResponse(currentSessionID string) SessionResponse {
+	return SessionResponse{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		Current:    s.ID == currentSessionID,
+	}
+}
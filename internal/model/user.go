@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"go-gin-sqlx-template/pkg/utils"
 )
 
 // User represents a user in the system
@@ -18,6 +20,18 @@ type User struct {
 	Name string `db:"name" json:"name"`
 	// The password of the user (not returned in JSON)
 	Password string `db:"password" json:"-"`
+	// Time the email address was verified, nil if not verified yet
+	EmailVerifiedAt *time.Time `db:"email_verified_at" json:"email_verified_at"`
+	// Time the user was soft deleted, nil for active users
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	// Row version, incremented on every change and exposed as the ETag
+	Version int64 `db:"version" json:"version"`
+	// Storage key of the uploaded avatar, nil without avatar
+	AvatarKey *string `db:"avatar_key" json:"-"`
+	// Public URL of the uploaded avatar
+	AvatarURL *string `db:"avatar_url" json:"avatar_url"`
+	// Public URLs of the avatar thumbnails by size, empty until they are generated
+	AvatarThumbnails AvatarThumbnails `db:"avatar_thumbnails" json:"avatar_thumbnails"`
 	// Creation time
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	// Last update time
@@ -31,25 +45,39 @@ type User struct {
 type CreateUserRequest struct {
 	// The email address
 	// required: true
-	Email string `json:"email" binding:"required,email" example:"user@gmail.com"`
+	Email string `json:"email" binding:"required,email,email_domain" example:"user@gmail.com"`
 	// The user's name
 	// required: true
 	// min length: 3
 	Name string `json:"name" binding:"required,min=3,max=100" example:"user"`
-	// The password
+	// The password, with upper and lower case letters and a digit
 	// required: true
-	// min length: 6
-	Password string `json:"password" binding:"required,min=6" example:"password"`
+	// min length: 8
+	Password string `json:"password" binding:"required,max_bytes=72,strong_password" example:"Passw0rd123"`
 }
 
-// UpdateUserRequest represents the payload for updating a user
+// UpdateUserRequest represents the payload for replacing a user with PUT.
+// Every field is required, use PatchUserRequest for partial updates.
 // swagger:model UpdateUserRequest
 type UpdateUserRequest struct {
+	// The email address
+	// required: true
+	Email string `json:"email" binding:"required,email,email_domain" example:"user@gmail.com"`
+	// The name
+	// required: true
+	// min length: 3
+	Name string `json:"name" binding:"required,min=3,max=100" example:"user"`
+}
+
+// PatchUserRequest represents a JSON Merge Patch (RFC 7396) document for a user.
+// Absent fields are left unchanged, null removes a field where the field is nullable.
+// swagger:model PatchUserRequest
+type PatchUserRequest struct {
 	// The new email address
-	Email string `json:"email" binding:"omitempty,email" example:"user@gmail.com"`
+	Email utils.Optional[string] `json:"email" binding:"omitempty,email,email_domain" swaggertype:"string" example:"user@gmail.com"`
 	// The new name
 	// min length: 3
-	Name string `json:"name" binding:"omitempty,min=3,max=100" example:"user"`
+	Name utils.Optional[string] `json:"name" binding:"omitempty,min=3,max=100" swaggertype:"string" example:"user"`
 }
 
 // UserResponse represents the user response data
@@ -61,6 +89,18 @@ type UserResponse struct {
 	Email string `json:"email" example:"user@gmail.com"`
 	// The user name
 	Name string `json:"name" example:"user"`
+	// Whether the email address has been verified
+	EmailVerified bool `json:"email_verified" example:"true"`
+	// Time the email address was verified
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" example:"2025-12-06T17:20:00+07:00"`
+	// Row version, also sent as the ETag header
+	Version int64 `json:"version" example:"1"`
+	// URL of the uploaded avatar
+	AvatarURL *string `json:"avatar_url,omitempty" example:"https://cdn.example.com/avatars/1/3f9a1c.png"`
+	// URLs of the avatar thumbnails by size in pixels, present once they are generated
+	AvatarThumbnails AvatarThumbnails `json:"avatar_thumbnails,omitempty" swaggertype:"object"`
+	// Time the user was deleted, only present when listing deleted users
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2025-12-06T17:30:00+07:00"`
 	// Creation time
 	CreatedAt time.Time `json:"created_at" example:"2025-12-06T17:16:43+07:00"`
 	// Last update time
@@ -69,10 +109,16 @@ type UserResponse struct {
 
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:               u.ID,
+		Email:            u.Email,
+		Name:             u.Name,
+		EmailVerified:    u.EmailVerifiedAt != nil,
+		EmailVerifiedAt:  u.EmailVerifiedAt,
+		Version:          u.Version,
+		AvatarURL:        u.AvatarURL,
+		AvatarThumbnails: u.AvatarThumbnails,
+		DeletedAt:        u.DeletedAt,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
 	}
 }
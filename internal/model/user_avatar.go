@@ -0,0 +1,41 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// AvatarThumbnailSizes are the edge lengths in pixels of the square thumbnails generated for avatars
+var AvatarThumbnailSizes = []int{64, 128, 256}
+
+// Avatar content types accepted for upload
+const (
+	AvatarContentTypeJPEG = "image/jpeg"
+	AvatarContentTypePNG  = "image/png"
+	AvatarContentTypeGIF  = "image/gif"
+)
+
+// AvatarThumbnails maps thumbnail sizes such as "64" to their URL, it is stored as a JSONB column
+type AvatarThumbnails map[string]string
+
+func (t AvatarThumbnails) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+func (t *AvatarThumbnails) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return fmt.Errorf("cannot scan %T into AvatarThumbnails", src)
+	}
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+
+	"go-gin-sqlx-template/pkg/utils"
+)
+
+// Batch operation types
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// BatchUserRequest represents a list of user operations applied in one request
+// swagger:model BatchUserRequest
+type BatchUserRequest struct {
+	// Apply every operation in one transaction, or none of them if one fails.
+	// Otherwise each operation is applied on its own.
+	Atomic bool `json:"atomic" example:"true"`
+	// The operations, applied in order
+	// required: true
+	Operations []BatchUserOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// BatchUserOperation represents one operation of a batch request
+// swagger:model BatchUserOperation
+type BatchUserOperation struct {
+	// One of create, update, delete
+	// required: true
+	Op string `json:"op" binding:"required,oneof=create update delete" example:"update"`
+	// The user ID, required for update and delete
+	ID int64 `json:"id,omitempty" binding:"required_unless=Op create" example:"1"`
+	// ETag of the version being modified, like the If-Match header
+	IfMatch string `json:"if_match,omitempty" example:"\"3\""`
+	// A CreateUserRequest for create, a PatchUserRequest for update, absent for delete
+	Data json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
+
+// BatchUserResult represents the outcome of one batch operation
+// swagger:model BatchUserResult
+type BatchUserResult struct {
+	// Position of the operation in the request
+	Index int `json:"index" example:"0"`
+	// The operation type
+	Op string `json:"op" example:"update"`
+	// HTTP status code the operation would have had as a single request
+	Status int `json:"status" example:"200"`
+	// Why the operation failed
+	Error string `json:"error,omitempty" example:"Email already exists"`
+	// The fields of the operation data that failed validation
+	Errors []utils.ErrorDetail `json:"errors,omitempty"`
+	// The created or updated user
+	Data *UserResponse `json:"data,omitempty"`
+}
+
+// BatchUserResponse represents the results of a batch request
+// swagger:model BatchUserResponse
+type BatchUserResponse struct {
+	// Whether the batch ran in one transaction
+	Atomic bool `json:"atomic" example:"true"`
+	// Number of operations applied
+	Succeeded int `json:"succeeded" example:"2"`
+	// Number of operations not applied
+	Failed int `json:"failed" example:"0"`
+	// One result per operation, in request order
+	Results []BatchUserResult `json:"results"`
+}
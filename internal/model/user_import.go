@@ -0,0 +1,134 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// User import statuses
+const (
+	UserImportStatusPending    = "pending"
+	UserImportStatusProcessing = "processing"
+	UserImportStatusCompleted  = "completed"
+	UserImportStatusFailed     = "failed"
+)
+
+// User import file formats
+const (
+	UserImportFormatCSV    = "csv"
+	UserImportFormatNDJSON = "ndjson"
+)
+
+// UserImport represents a bulk user import processed by the worker.
+// The uploaded file is kept sealed in Data until the import finishes, its key is only in the task.
+type UserImport struct {
+	ID            int64     `db:"id"`
+	Status        string    `db:"status"`
+	Format        string    `db:"format"`
+	Filename      string    `db:"filename"`
+	Data          []byte    `db:"data"`
+	TotalRows     int       `db:"total_rows"`
+	ProcessedRows int       `db:"processed_rows"`
+	CreatedRows   int       `db:"created_rows"`
+	FailedRows    int       `db:"failed_rows"`
+	Errors        RowErrors `db:"errors"`
+	// Error is set when the whole file could not be processed
+	Error     *string `db:"error"`
+	CreatedBy *int64  `db:"created_by"`
+	// OrganizationID is the organization the imported users join
+	OrganizationID *int64     `db:"organization_id"`
+	CreatedAt      time.Time  `db:"created_at"`
+	StartedAt      *time.Time `db:"started_at"`
+	FinishedAt     *time.Time `db:"finished_at"`
+}
+
+// RowError reports why a row of an uploaded file was rejected
+type RowError struct {
+	// 1-based row number, not counting the CSV header
+	Row int `json:"row" example:"3"`
+	// The email of the row, if it could be read
+	Email string `json:"email,omitempty" example:"user@gmail.com"`
+	// Reasons the row was rejected
+	Errors []string `json:"errors" example:"email must be a valid email address"`
+}
+
+// RowErrors is stored as a JSONB column
+type RowErrors []RowError
+
+func (e RowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(e)
+}
+
+func (e *RowErrors) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into RowErrors", src)
+	}
+}
+
+// UserImportResponse represents the status of a bulk user import
+// swagger:model UserImportResponse
+type UserImportResponse struct {
+	// The import ID
+	ID int64 `json:"id" example:"1"`
+	// One of pending, processing, completed, failed
+	Status string `json:"status" example:"processing"`
+	// The file format, csv or ndjson
+	Format string `json:"format" example:"csv"`
+	// The uploaded file name
+	Filename string `json:"filename" example:"users.csv"`
+	// Number of rows in the file, known once processing starts
+	TotalRows int `json:"total_rows" example:"1000"`
+	// Number of rows handled so far
+	ProcessedRows int `json:"processed_rows" example:"500"`
+	// Number of users created
+	CreatedRows int `json:"created_rows" example:"498"`
+	// Number of rows rejected
+	FailedRows int `json:"failed_rows" example:"2"`
+	// Why each rejected row failed
+	Errors []RowError `json:"errors"`
+	// Why the whole import failed
+	Error *string `json:"error,omitempty" example:"csv header must contain email, name and password"`
+	// Creation time
+	CreatedAt time.Time `json:"created_at" example:"2025-12-06T17:16:43+07:00"`
+	// Time the worker started processing
+	StartedAt *time.Time `json:"started_at,omitempty" example:"2025-12-06T17:16:44+07:00"`
+	// Time the import completed or failed
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2025-12-06T17:18:02+07:00"`
+}
+
+// ToResponse converts UserImport to UserImportResponse
+func (i *UserImport) ToResponse() UserImportResponse {
+	errors := []RowError(i.Errors)
+	if errors == nil {
+		errors = []RowError{}
+	}
+
+	return UserImportResponse{
+		ID:            i.ID,
+		Status:        i.Status,
+		Format:        i.Format,
+		Filename:      i.Filename,
+		TotalRows:     i.TotalRows,
+		ProcessedRows: i.ProcessedRows,
+		CreatedRows:   i.CreatedRows,
+		FailedRows:    i.FailedRows,
+		Errors:        errors,
+		Error:         i.Error,
+		CreatedAt:     i.CreatedAt,
+		StartedAt:     i.StartedAt,
+		FinishedAt:    i.FinishedAt,
+	}
+}
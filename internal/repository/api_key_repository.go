@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+)
+
+var (
+	// ErrAPIKeyNotFound is returned when an API key does not exist
+	ErrAPIKeyNotFound = apperror.NotFound("api key not found")
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *model.APIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error)
+	// GetAll and Revoke only see the keys of the organization ctx is scoped to
+	GetAll(ctx context.Context) ([]model.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	TouchLastUsed(ctx context.Context, id int64) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/pkg/utils"
+)
+
+type AuditLogRepository interface {
+	// Create writes the audit log, call it with the transaction context of the change
+	Create(ctx context.Context, log *model.AuditLog) error
+	// CreateBatch writes many audit logs in one statement
+	CreateBatch(ctx context.Context, logs []*model.AuditLog) error
+	GetAll(ctx context.Context, pagination utils.PaginationParams, filters utils.FilterParams, sort []utils.SortParams) ([]model.AuditLog, error)
+	Count(ctx context.Context, filters utils.FilterParams) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+)
+
+var (
+	// ErrEmailVerificationNotFound is returned when a verification token is unknown, expired or already used
+	ErrEmailVerificationNotFound = apperror.NotFound("email verification token not found")
+)
+
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, token *model.EmailVerificationToken) error
+	// Consume atomically marks an unused, unexpired token as used and returns it
+	Consume(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error)
+	// InvalidateForUser marks every outstanding token of the user as used
+	InvalidateForUser(ctx context.Context, userID int64) error
+}
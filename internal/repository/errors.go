@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+
+	"go-gin-sqlx-template/internal/apperror"
+)
+
+// Errors of writes rejected by the database. Repositories return them inside a
+// ConstraintError, usecases that expect a violation check the constraint name.
+var (
+	// ErrUniqueViolation is returned when a write duplicates a unique key
+	ErrUniqueViolation = apperror.Conflict("resource already exists")
+
+	// ErrForeignKeyViolation is returned when a write references a missing row
+	// or removes a row that is still referenced
+	ErrForeignKeyViolation = apperror.Conflict("related resource does not exist or is still in use")
+
+	// ErrCheckViolation is returned when a write breaks a check constraint
+	ErrCheckViolation = apperror.Validation("value is not allowed")
+
+	// ErrSerializationFailure is returned when a transaction conflicts with a concurrent one,
+	// retrying it may succeed
+	ErrSerializationFailure = apperror.Conflict("concurrent update conflict, retry the request")
+)
+
+// ConstraintError is a write rejected by a database constraint or by a concurrent transaction
+type ConstraintError struct {
+	// Err is ErrUniqueViolation, ErrForeignKeyViolation, ErrCheckViolation or ErrSerializationFailure
+	Err error
+	// Constraint is the name of the violated constraint or unique index,
+	// empty for serialization failures
+	Constraint string
+	// Cause is the error of the database driver
+	Cause error
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Constraint == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Constraint
+}
+
+// Unwrap makes errors.Is match the kind of violation and errors.As find the driver error
+func (e *ConstraintError) Unwrap() []error {
+	return []error{e.Err, e.Cause}
+}
+
+// IsConstraintViolation reports whether err is a violation of the kind, one of the
+// Err* violations of this file, on the named constraint
+func IsConstraintViolation(err, kind error, constraint string) bool {
+	var constraintErr *ConstraintError
+	return errors.As(err, &constraintErr) && errors.Is(constraintErr.Err, kind) && constraintErr.Constraint == constraint
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+
+	"go-gin-sqlx-template/internal/apperror"
+)
+
+var (
+	// ErrIdentityNotFound is returned when no user is linked to the provider account
+	ErrIdentityNotFound = apperror.NotFound("identity not found")
+	// ErrOIDCStateNotFound is returned when a login state does not exist, has expired or was already used
+	ErrOIDCStateNotFound = apperror.NotFound("oidc state not found")
+)
+
+type IdentityRepository interface {
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error)
+	Create(ctx context.Context, identity *model.UserIdentity) error
+	TouchLastLogin(ctx context.Context, id int64) error
+}
+
+type OIDCStateRepository interface {
+	// Save stores the state until its ExpiresAt
+	Save(ctx context.Context, state *model.OIDCState) error
+	// Consume atomically returns and deletes the state, so each login can only be completed once
+	Consume(ctx context.Context, stateHash string) (*model.OIDCState, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttemptRepository tracks failed logins and lockouts.
+// Keys identify what is being throttled, e.g. an account or a client IP.
+type LoginAttemptRepository interface {
+	// IncrementFailures records a failed attempt and returns the number of
+	// failures since the key was last quiet for the whole window
+	IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	// ResetFailures clears the failure counter of the key
+	ResetFailures(ctx context.Context, key string) error
+	// RecordLockout counts a lockout of the key and returns the number of lockouts
+	// since the key was last quiet for the whole memory period
+	RecordLockout(ctx context.Context, key string, memory time.Duration) (int64, error)
+	// Lock locks the key for the given duration and clears its failure counter
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	// LockRemaining returns how long the key stays locked, zero if it is not locked
+	LockRemaining(ctx context.Context, key string) (time.Duration, error)
+	// Unlock removes the lock, the failure counter and the lockout count of the key
+	Unlock(ctx context.Context, key string) error
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/internal/repository"
+)
+
+// slidingCounter is a counter that starts over once it has not been incremented for its ttl
+type slidingCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// loginAttemptRepository is an in-memory LoginAttemptRepository intended for tests
+// and local development without Redis
+type loginAttemptRepository struct {
+	mu       sync.Mutex
+	failures map[string]slidingCounter
+	lockouts map[string]slidingCounter
+	locks    map[string]time.Time
+	now      func() time.Time
+}
+
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		failures: make(map[string]slidingCounter),
+		lockouts: make(map[string]slidingCounter),
+		locks:    make(map[string]time.Time),
+		now:      time.Now,
+	}
+}
+
+func (r *loginAttemptRepository) IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.incrementLocked(r.failures, key, window), nil
+}
+
+func (r *loginAttemptRepository) RecordLockout(ctx context.Context, key string, memory time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.incrementLocked(r.lockouts, key, memory), nil
+}
+
+func (r *loginAttemptRepository) ResetFailures(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, duration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.locks[key] = r.now().Add(duration)
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *loginAttemptRepository) LockRemaining(ctx context.Context, key string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	until, ok := r.locks[key]
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := until.Sub(r.now())
+	if remaining <= 0 {
+		delete(r.locks, key)
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+func (r *loginAttemptRepository) Unlock(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.locks, key)
+	delete(r.failures, key)
+	delete(r.lockouts, key)
+	return nil
+}
+
+// incrementLocked increments the counter of the key, starting over if it expired.
+// Must be called with r.mu held.
+func (r *loginAttemptRepository) incrementLocked(counters map[string]slidingCounter, key string, ttl time.Duration) int64 {
+	now := r.now()
+	counter := counters[key]
+	if !now.Before(counter.expiresAt) {
+		counter = slidingCounter{}
+	}
+	counter.count++
+	counter.expiresAt = now.Add(ttl)
+	counters[key] = counter
+
+	return counter.count
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+)
+
+// mfaChallengeRepository is an in-memory MFAChallengeRepository intended for tests
+// and local development without Redis
+type mfaChallengeRepository struct {
+	mu         sync.Mutex
+	challenges map[string]model.MFAChallenge
+	now        func() time.Time
+}
+
+func NewMFAChallengeRepository() repository.MFAChallengeRepository {
+	return &mfaChallengeRepository{
+		challenges: make(map[string]model.MFAChallenge),
+		now:        time.Now,
+	}
+}
+
+func (r *mfaChallengeRepository) Create(ctx context.Context, challenge *model.MFAChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.challenges[challenge.TokenHash] = *challenge
+	return nil
+}
+
+func (r *mfaChallengeRepository) Get(ctx context.Context, tokenHash string) (*model.MFAChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	challenge, ok := r.getLocked(tokenHash)
+	if !ok {
+		return nil, repository.ErrMFAChallengeNotFound
+	}
+
+	return &challenge, nil
+}
+
+func (r *mfaChallengeRepository) IncrementAttempts(ctx context.Context, tokenHash string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	challenge, ok := r.getLocked(tokenHash)
+	if !ok {
+		return 0, repository.ErrMFAChallengeNotFound
+	}
+
+	challenge.Attempts++
+	r.challenges[tokenHash] = challenge
+	return challenge.Attempts, nil
+}
+
+func (r *mfaChallengeRepository) Delete(ctx context.Context, tokenHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.getLocked(tokenHash)
+	delete(r.challenges, tokenHash)
+	return ok, nil
+}
+
+// getLocked returns the challenge unless it has expired. The caller must hold r.mu.
+func (r *mfaChallengeRepository) getLocked(tokenHash string) (model.MFAChallenge, bool) {
+	challenge, ok := r.challenges[tokenHash]
+	if !ok {
+		return model.MFAChallenge{}, false
+	}
+	if !r.now().Before(challenge.ExpiresAt) {
+		delete(r.challenges, tokenHash)
+		return model.MFAChallenge{}, false
+	}
+	return challenge, true
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+)
+
+// oidcStateRepository is an in-memory OIDCStateRepository intended for tests
+// and local development without Redis
+type oidcStateRepository struct {
+	mu     sync.Mutex
+	states map[string]model.OIDCState
+	now    func() time.Time
+}
+
+func NewOIDCStateRepository() repository.OIDCStateRepository {
+	return &oidcStateRepository{
+		states: make(map[string]model.OIDCState),
+		now:    time.Now,
+	}
+}
+
+func (r *oidcStateRepository) Save(ctx context.Context, state *model.OIDCState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[state.StateHash] = *state
+	return nil
+}
+
+func (r *oidcStateRepository) Consume(ctx context.Context, stateHash string) (*model.OIDCState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.states[stateHash]
+	delete(r.states, stateHash)
+	if !ok || !r.now().Before(state.ExpiresAt) {
+		return nil, repository.ErrOIDCStateNotFound
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+)
+
+// sessionRepository is an in-memory SessionRepository intended for tests
+// and local development without Redis
+type sessionRepository struct {
+	mu       sync.Mutex
+	tokens   map[string]model.RefreshToken
+	families map[string]map[string]struct{}
+	users    map[int64]map[string]struct{}
+	sessions map[string]model.Session
+	now      func() time.Time
+}
+
+func NewSessionRepository() repository.SessionRepository {
+	return &sessionRepository{
+		tokens:   make(map[string]model.RefreshToken),
+		families: make(map[string]map[string]struct{}),
+		users:    make(map[int64]map[string]struct{}),
+		sessions: make(map[string]model.Session),
+		now:      time.Now,
+	}
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, session *model.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.ID] = *session
+
+	userFamilies, ok := r.users[session.UserID]
+	if !ok {
+		userFamilies = make(map[string]struct{})
+		r.users[session.UserID] = userFamilies
+	}
+	userFamilies[session.ID] = struct{}{}
+
+	return nil
+}
+
+func (r *sessionRepository) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.getSessionLocked(sessionID)
+	if !ok {
+		return nil, repository.ErrSessionNotFound
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) ListSessions(ctx context.Context, userID int64) ([]model.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessions := make([]model.Session, 0, len(r.users[userID]))
+	for familyID := range r.users[userID] {
+		if session, ok := r.getSessionLocked(familyID); ok {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) TouchSession(ctx context.Context, sessionID string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.getSessionLocked(sessionID)
+	if !ok {
+		return false, nil
+	}
+
+	session.LastSeenAt = at
+	r.sessions[sessionID] = session
+	return true, nil
+}
+
+func (r *sessionRepository) Save(ctx context.Context, token *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.TokenHash] = *token
+
+	family, ok := r.families[token.FamilyID]
+	if !ok {
+		family = make(map[string]struct{})
+		r.families[token.FamilyID] = family
+	}
+	family[token.TokenHash] = struct{}{}
+
+	userFamilies, ok := r.users[token.UserID]
+	if !ok {
+		userFamilies = make(map[string]struct{})
+		r.users[token.UserID] = userFamilies
+	}
+	userFamilies[token.FamilyID] = struct{}{}
+
+	if session, ok := r.sessions[token.FamilyID]; ok {
+		session.ExpiresAt = token.ExpiresAt
+		r.sessions[token.FamilyID] = session
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) Get(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.getLocked(tokenHash)
+	if !ok {
+		return nil, repository.ErrSessionNotFound
+	}
+
+	return &token, nil
+}
+
+func (r *sessionRepository) MarkRotated(ctx context.Context, tokenHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.getLocked(tokenHash)
+	if !ok {
+		return false, repository.ErrSessionNotFound
+	}
+	if token.IsRotated() {
+		return false, nil
+	}
+
+	now := r.now()
+	token.RotatedAt = &now
+	r.tokens[tokenHash] = token
+
+	return true, nil
+}
+
+func (r *sessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash := range r.families[familyID] {
+		delete(r.tokens, hash)
+	}
+	delete(r.families, familyID)
+	delete(r.sessions, familyID)
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for familyID := range r.users[userID] {
+		for hash := range r.families[familyID] {
+			delete(r.tokens, hash)
+		}
+		delete(r.families, familyID)
+		delete(r.sessions, familyID)
+	}
+	delete(r.users, userID)
+
+	return nil
+}
+
+// getLocked returns the token if it exists and has not expired.
+// Must be called with r.mu held.
+func (r *sessionRepository) getLocked(tokenHash string) (model.RefreshToken, bool) {
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return model.RefreshToken{}, false
+	}
+	if !r.now().Before(token.ExpiresAt) {
+		delete(r.tokens, tokenHash)
+		return model.RefreshToken{}, false
+	}
+	return token, true
+}
+
+// getSessionLocked returns the session if it exists and has not expired.
+// Must be called with r.mu held.
+func (r *sessionRepository) getSessionLocked(sessionID string) (model.Session, bool) {
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return model.Session{}, false
+	}
+	if !r.now().Before(session.ExpiresAt) {
+		delete(r.sessions, sessionID)
+		return model.Session{}, false
+	}
+	return session, true
+}
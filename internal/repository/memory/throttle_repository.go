@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/internal/repository"
+)
+
+// throttleRepository is an in-memory ThrottleRepository intended for tests
+// and local development without Redis
+type throttleRepository struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+	now  func() time.Time
+}
+
+func NewThrottleRepository() repository.ThrottleRepository {
+	return &throttleRepository{
+		keys: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+func (r *throttleRepository) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if expiresAt, ok := r.keys[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	r.keys[key] = now.Add(ttl)
+	return true, nil
+}
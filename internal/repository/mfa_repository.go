@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+
+	"go-gin-sqlx-template/internal/apperror"
+)
+
+var (
+	// ErrMFANotFound is returned when the user has not enrolled in two-factor authentication
+	ErrMFANotFound = apperror.NotFound("mfa not found")
+	// ErrMFAChallengeNotFound is returned when a login challenge does not exist or has expired
+	ErrMFAChallengeNotFound = apperror.NotFound("mfa challenge not found")
+)
+
+type MFARepository interface {
+	GetByUserID(ctx context.Context, userID int64) (*model.UserMFA, error)
+	// Upsert stores a new unconfirmed secret, replacing an earlier unconfirmed one.
+	// It returns false if the user already confirmed an enrollment.
+	Upsert(ctx context.Context, mfa *model.UserMFA) (bool, error)
+	// Confirm enables the enrollment and records the step of the code used to confirm it
+	Confirm(ctx context.Context, userID int64, step int64) error
+	// UseStep atomically records a TOTP step as used.
+	// It returns false if the same or a later step was already used, so codes cannot be replayed.
+	UseStep(ctx context.Context, userID int64, step int64) (bool, error)
+	// Delete removes the enrollment and every recovery code of the user
+	Delete(ctx context.Context, userID int64) error
+	// ReplaceRecoveryCodes deletes the existing recovery codes and stores the given hashes
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	// ConsumeRecoveryCode atomically marks an unused recovery code as used.
+	// It returns false if no such code exists.
+	ConsumeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+}
+
+type MFAChallengeRepository interface {
+	// Create stores the challenge until its ExpiresAt
+	Create(ctx context.Context, challenge *model.MFAChallenge) error
+	// Get returns the challenge by its token hash
+	Get(ctx context.Context, tokenHash string) (*model.MFAChallenge, error)
+	// IncrementAttempts counts a wrong code and returns the new number of attempts
+	IncrementAttempts(ctx context.Context, tokenHash string) (int64, error)
+	// Delete removes the challenge.
+	// It returns false if it had already been removed, so a challenge is only redeemed once.
+	Delete(ctx context.Context, tokenHash string) (bool, error)
+}
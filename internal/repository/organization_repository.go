@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/pkg/utils"
+)
+
+var (
+	// ErrOrganizationNotFound is returned when an organization does not exist
+	ErrOrganizationNotFound = apperror.NotFound("organization not found")
+
+	// ErrMembershipNotFound is returned when removing a user who is not a member of the organization
+	ErrMembershipNotFound = apperror.NotFound("membership not found")
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *model.Organization) error
+	GetByID(ctx context.Context, id int64) (*model.Organization, error)
+	GetAll(ctx context.Context, pagination utils.PaginationParams, sort []utils.SortParams) ([]model.Organization, error)
+	Count(ctx context.Context) (int64, error)
+	// GetByUserID returns the organizations the user is a member of, in the order they joined
+	GetByUserID(ctx context.Context, userID int64) ([]model.Organization, error)
+	IsMember(ctx context.Context, organizationID, userID int64) (bool, error)
+	// AddMember adds the user to the organization, it returns false if they already were a member
+	AddMember(ctx context.Context, organizationID, userID int64) (bool, error)
+	RemoveMember(ctx context.Context, organizationID, userID int64) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+)
+
+var (
+	// ErrPasswordResetNotFound is returned when a reset token is unknown, expired or already used
+	ErrPasswordResetNotFound = apperror.NotFound("password reset token not found")
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *model.PasswordResetToken) error
+	// Consume atomically marks an unused, unexpired token as used and returns it
+	Consume(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error)
+	// InvalidateForUser marks every outstanding token of the user as used
+	InvalidateForUser(ctx context.Context, userID int64) error
+}
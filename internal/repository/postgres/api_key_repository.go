@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/tenant"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, organization_id, expires_at, last_used_at, revoked_at, created_at`
+
+type apiKeyRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewAPIKeyRepository(db *sqlx.DB, transactor database.Transactor) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *apiKeyRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, organization_id, expires_at, created_at)
+		VALUES (:name, :prefix, :key_hash, :scopes, :created_by, :organization_id, :expires_at, NOW())
+		RETURNING id, created_at
+	`
+	args := map[string]any{
+		"name":            apiKey.Name,
+		"prefix":          apiKey.Prefix,
+		"key_hash":        apiKey.KeyHash,
+		"scopes":          apiKey.Scopes,
+		"created_by":      apiKey.CreatedBy,
+		"organization_id": apiKey.OrganizationID,
+		"expires_at":      apiKey.ExpiresAt,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if row.Next() {
+		err = row.Scan(&apiKey.ID, &apiKey.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan created api key: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	var apiKey model.APIKey
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = :prefix`
+
+	args := map[string]any{
+		"prefix": prefix,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+
+	err = row.StructScan(&apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan api key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+// andAPIKeyTenantScope limits API keys to the organization ctx is scoped to,
+// or returns an empty string when ctx is not scoped
+func andAPIKeyTenantScope(ctx context.Context, args map[string]any) string {
+	organizationID, ok := tenant.OrganizationIDFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	args["tenant_organization_id"] = organizationID
+	return " AND organization_id = :tenant_organization_id"
+}
+
+func (r *apiKeyRepository) GetAll(ctx context.Context) ([]model.APIKey, error) {
+	var apiKeys []model.APIKey
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys`
+
+	args := map[string]any{}
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		query += ` WHERE organization_id = :tenant_organization_id`
+		args["tenant_organization_id"] = organizationID
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+	defer rows.Close()
+
+	err = sqlx.StructScan(rows, &apiKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id int64) error {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = :id AND revoked_at IS NULL`
+
+	args := map[string]any{
+		"id": id,
+	}
+	query += andAPIKeyTenantScope(ctx, args)
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records usage at most once per minute to avoid a write on every request
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id int64) error {
+	query := `
+		UPDATE api_keys SET last_used_at = NOW()
+		WHERE id = :id AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+	args := map[string]any{
+		"id": id,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", translateError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/tenant"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type auditLogRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewAuditLogRepository(db *sqlx.DB, transactor database.Transactor) repository.AuditLogRepository {
+	return &auditLogRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *auditLogRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *model.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (actor_user_id, actor_api_key_id, impersonator_user_id, organization_id, action, entity_type, entity_id, changes, request_id, ip_address, created_at)
+		VALUES (:actor_user_id, :actor_api_key_id, :impersonator_user_id, :organization_id, :action, :entity_type, :entity_id, :changes, :request_id, :ip_address, NOW())
+		RETURNING id, created_at
+	`
+	args := map[string]any{
+		"actor_user_id":        log.ActorUserID,
+		"actor_api_key_id":     log.ActorAPIKeyID,
+		"impersonator_user_id": log.ImpersonatorUserID,
+		"organization_id":      log.OrganizationID,
+		"action":               log.Action,
+		"entity_type":          log.EntityType,
+		"entity_id":            log.EntityID,
+		"changes":              log.Changes,
+		"request_id":           log.RequestID,
+		"ip_address":           log.IPAddress,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if row.Next() {
+		err = row.Scan(&log.ID, &log.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan created audit log: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *auditLogRepository) CreateBatch(ctx context.Context, logs []*model.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(logs))
+	args := make(map[string]any, len(logs)*10)
+	for i, log := range logs {
+		values[i] = fmt.Sprintf(
+			"(:actor_user_id_%d, :actor_api_key_id_%d, :impersonator_user_id_%d, :organization_id_%d, :action_%d, :entity_type_%d, :entity_id_%d, :changes_%d, :request_id_%d, :ip_address_%d, NOW())",
+			i, i, i, i, i, i, i, i, i, i,
+		)
+		args[fmt.Sprintf("actor_user_id_%d", i)] = log.ActorUserID
+		args[fmt.Sprintf("actor_api_key_id_%d", i)] = log.ActorAPIKeyID
+		args[fmt.Sprintf("impersonator_user_id_%d", i)] = log.ImpersonatorUserID
+		args[fmt.Sprintf("organization_id_%d", i)] = log.OrganizationID
+		args[fmt.Sprintf("action_%d", i)] = log.Action
+		args[fmt.Sprintf("entity_type_%d", i)] = log.EntityType
+		args[fmt.Sprintf("entity_id_%d", i)] = log.EntityID
+		args[fmt.Sprintf("changes_%d", i)] = log.Changes
+		args[fmt.Sprintf("request_id_%d", i)] = log.RequestID
+		args[fmt.Sprintf("ip_address_%d", i)] = log.IPAddress
+	}
+
+	query := `
+		INSERT INTO audit_logs (actor_user_id, actor_api_key_id, impersonator_user_id, organization_id, action, entity_type, entity_id, changes, request_id, ip_address, created_at)
+		VALUES ` + strings.Join(values, ", ")
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create audit logs: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *auditLogRepository) GetAll(ctx context.Context, pagination utils.PaginationParams, filters utils.FilterParams, sort []utils.SortParams) ([]model.AuditLog, error) {
+	var logs []model.AuditLog
+
+	args := map[string]any{
+		"limit":  pagination.Limit,
+		"offset": pagination.Offset,
+	}
+
+	qb := utils.NewQueryBuilder(`
+		SELECT id, actor_user_id, actor_api_key_id, impersonator_user_id, organization_id, action, entity_type, entity_id, changes, request_id, ip_address, created_at
+		FROM audit_logs`)
+	addAuditLogFilters(ctx, qb, filters, args)
+	qb.SetOrderBy(sort)
+	qb.SetLimitOffset("LIMIT :limit", "OFFSET :offset")
+
+	query := qb.Build()
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	err = sqlx.StructScan(rows, &logs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan audit logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+func (r *auditLogRepository) Count(ctx context.Context, filters utils.FilterParams) (int64, error) {
+	var count int64
+
+	args := map[string]any{}
+
+	qb := utils.NewQueryBuilder("SELECT COUNT(*) FROM audit_logs")
+	addAuditLogFilters(ctx, qb, filters, args)
+
+	query := qb.Build()
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		err = rows.Scan(&count)
+		if err != nil {
+			return 0, fmt.Errorf("failed to scan count: %w", err)
+		}
+	}
+
+	return count, nil
+}
+
+// auditLogTenantScope limits audit logs to the organization ctx is scoped to,
+// or returns an empty string when ctx is not scoped
+func auditLogTenantScope(ctx context.Context, args map[string]any) string {
+	organizationID, ok := tenant.OrganizationIDFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	args["tenant_organization_id"] = organizationID
+	return "organization_id = :tenant_organization_id"
+}
+
+// auditLogFilterColumns maps the exact match filters of the audit log listing to their columns
+var auditLogFilterColumns = []struct{ filter, column string }{
+	{"action", "action"},
+	{"entity_type", "entity_type"},
+	{"entity_id", "entity_id"},
+	{"actor_id", "actor_user_id"},
+	{"impersonator_id", "impersonator_user_id"},
+	{"request_id", "request_id"},
+}
+
+// addAuditLogFilters applies the tenant scope of ctx, the exact match filters and the since/until time range
+func addAuditLogFilters(ctx context.Context, qb *utils.QueryBuilder, filters utils.FilterParams, args map[string]any) {
+	qb.AddWhere(auditLogTenantScope(ctx, args))
+
+	for _, f := range auditLogFilterColumns {
+		if value, ok := filters.Get(f.filter); ok {
+			qb.AddWhere(f.column + " = :" + f.filter)
+			args[f.filter] = value
+		}
+	}
+
+	if since, ok := filters.Get("since"); ok {
+		qb.AddWhere("created_at >= :since")
+		args["since"] = since
+	}
+
+	if until, ok := filters.Get("until"); ok {
+		qb.AddWhere("created_at < :until")
+		args["until"] = until
+	}
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"errors"
+
+	"go-gin-sqlx-template/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+// pqViolations maps the Postgres error codes of rejected writes to their repository errors
+var pqViolations = map[pq.ErrorCode]error{
+	"23505": repository.ErrUniqueViolation,
+	"23503": repository.ErrForeignKeyViolation,
+	"23514": repository.ErrCheckViolation,
+	"40001": repository.ErrSerializationFailure,
+}
+
+// translateError returns a repository.ConstraintError with the constraint name for
+// constraint violations and serialization failures, other errors are returned unchanged
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	violation, ok := pqViolations[pqErr.Code]
+	if !ok {
+		return err
+	}
+	return &repository.ConstraintError{
+		Err:        violation,
+		Constraint: pqErr.Constraint,
+		Cause:      err,
+	}
+}
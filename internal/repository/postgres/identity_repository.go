@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type identityRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewIdentityRepository(db *sqlx.DB, transactor database.Transactor) repository.IdentityRepository {
+	return &identityRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *identityRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *identityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error) {
+	var identity model.UserIdentity
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE provider = :provider AND subject = :subject
+	`
+	args := map[string]any{
+		"provider": provider,
+		"subject":  subject,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrIdentityNotFound
+	}
+
+	err = row.StructScan(&identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+func (r *identityRepository) Create(ctx context.Context, identity *model.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, last_login_at, created_at)
+		VALUES (:user_id, :provider, :subject, :email, NOW(), NOW())
+		RETURNING id, last_login_at, created_at
+	`
+	args := map[string]any{
+		"user_id":  identity.UserID,
+		"provider": identity.Provider,
+		"subject":  identity.Subject,
+		"email":    identity.Email,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create identity: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if row.Next() {
+		err = row.Scan(&identity.ID, &identity.LastLoginAt, &identity.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan created identity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *identityRepository) TouchLastLogin(ctx context.Context, id int64) error {
+	query := `UPDATE user_identities SET last_login_at = NOW() WHERE id = :id`
+
+	args := map[string]any{
+		"id": id,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to update identity last login: %w", translateError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type mfaRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewMFARepository(db *sqlx.DB, transactor database.Transactor) repository.MFARepository {
+	return &mfaRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *mfaRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *mfaRepository) GetByUserID(ctx context.Context, userID int64) (*model.UserMFA, error) {
+	var mfa model.UserMFA
+	query := `SELECT user_id, secret, confirmed_at, last_used_step, created_at FROM user_mfa WHERE user_id = :user_id`
+
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrMFANotFound
+	}
+
+	err = row.StructScan(&mfa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan mfa: %w", err)
+	}
+
+	return &mfa, nil
+}
+
+func (r *mfaRepository) Upsert(ctx context.Context, mfa *model.UserMFA) (bool, error) {
+	query := `
+		INSERT INTO user_mfa (user_id, secret, created_at)
+		VALUES (:user_id, :secret, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_mfa.confirmed_at IS NULL
+		RETURNING created_at
+	`
+	args := map[string]any{
+		"user_id": mfa.UserID,
+		"secret":  mfa.Secret,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return false, fmt.Errorf("failed to store mfa: %w", translateError(err))
+	}
+	defer row.Close()
+
+	// No row means the conflicting enrollment is already confirmed
+	if !row.Next() {
+		return false, nil
+	}
+
+	err = row.Scan(&mfa.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to scan stored mfa: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mfaRepository) Confirm(ctx context.Context, userID int64, step int64) error {
+	query := `
+		UPDATE user_mfa SET confirmed_at = NOW(), last_used_step = :step
+		WHERE user_id = :user_id AND confirmed_at IS NULL
+	`
+	args := map[string]any{
+		"user_id": userID,
+		"step":    step,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to confirm mfa: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrMFANotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) UseStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	query := `
+		UPDATE user_mfa SET last_used_step = :step
+		WHERE user_id = :user_id AND confirmed_at IS NOT NULL AND last_used_step < :step
+	`
+	args := map[string]any{
+		"user_id": userID,
+		"step":    step,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return false, fmt.Errorf("failed to use mfa step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID int64) error {
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), `DELETE FROM mfa_recovery_codes WHERE user_id = :user_id`, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", translateError(err))
+	}
+
+	_, err = sqlx.NamedExecContext(ctx, r.getExecutor(ctx), `DELETE FROM user_mfa WHERE user_id = :user_id`, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to delete mfa: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), `DELETE FROM mfa_recovery_codes WHERE user_id = :user_id`, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", translateError(err))
+	}
+
+	query := `INSERT INTO mfa_recovery_codes (user_id, code_hash, created_at) VALUES (:user_id, :code_hash, NOW())`
+	for _, codeHash := range codeHashes {
+		args["code_hash"] = codeHash
+
+		_, err = sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+		if err != nil {
+			return fmt.Errorf("failed to store recovery code: %w", translateError(err))
+		}
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) ConsumeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = NOW()
+		WHERE user_id = :user_id AND code_hash = :code_hash AND used_at IS NULL
+	`
+	args := map[string]any{
+		"user_id":   userID,
+		"code_hash": codeHash,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return false, fmt.Errorf("failed to consume recovery code: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
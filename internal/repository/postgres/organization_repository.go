@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type organizationRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewOrganizationRepository(db *sqlx.DB, transactor database.Transactor) repository.OrganizationRepository {
+	return &organizationRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *organizationRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *model.Organization) error {
+	query := `
+		INSERT INTO organizations (name, created_at, updated_at)
+		VALUES (:name, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+	args := map[string]any{
+		"name": organization.Name,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if row.Next() {
+		err = row.Scan(&organization.ID, &organization.CreatedAt, &organization.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan created organization: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id int64) (*model.Organization, error) {
+	var organization model.Organization
+	query := `SELECT id, name, created_at, updated_at FROM organizations WHERE id = :id`
+
+	args := map[string]any{
+		"id": id,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrOrganizationNotFound
+	}
+
+	err = row.StructScan(&organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan organization: %w", err)
+	}
+
+	return &organization, nil
+}
+
+func (r *organizationRepository) GetAll(ctx context.Context, pagination utils.PaginationParams, sort []utils.SortParams) ([]model.Organization, error) {
+	var organizations []model.Organization
+
+	args := map[string]any{
+		"limit":  pagination.Limit,
+		"offset": pagination.Offset,
+	}
+
+	qb := utils.NewQueryBuilder("SELECT id, name, created_at, updated_at FROM organizations")
+	qb.SetOrderBy(sort)
+	qb.SetLimitOffset("LIMIT :limit", "OFFSET :offset")
+
+	query := qb.Build()
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	defer rows.Close()
+
+	err = sqlx.StructScan(rows, &organizations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (r *organizationRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := sqlx.GetContext(ctx, r.getExecutor(ctx), &count, "SELECT COUNT(*) FROM organizations")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count organizations: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *organizationRepository) GetByUserID(ctx context.Context, userID int64) ([]model.Organization, error) {
+	var organizations []model.Organization
+	query := `
+		SELECT o.id, o.name, o.created_at, o.updated_at
+		FROM organizations o
+		JOIN organization_members om ON om.organization_id = o.id
+		WHERE om.user_id = :user_id
+		ORDER BY om.created_at ASC, o.id ASC
+	`
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+	defer rows.Close()
+
+	err = sqlx.StructScan(rows, &organizations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (r *organizationRepository) IsMember(ctx context.Context, organizationID, userID int64) (bool, error) {
+	var isMember bool
+	query := `SELECT EXISTS (SELECT 1 FROM organization_members WHERE organization_id = :organization_id AND user_id = :user_id)`
+
+	args := map[string]any{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	}
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization membership: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&isMember); err != nil {
+			return false, fmt.Errorf("failed to scan organization membership: %w", err)
+		}
+	}
+
+	return isMember, nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, organizationID, userID int64) (bool, error) {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, created_at)
+		VALUES (:organization_id, :user_id, NOW())
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+	`
+	args := map[string]any{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return false, fmt.Errorf("failed to add organization member: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	query := `DELETE FROM organization_members WHERE organization_id = :organization_id AND user_id = :user_id`
+
+	args := map[string]any{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrMembershipNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type passwordResetRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewPasswordResetRepository(db *sqlx.DB, transactor database.Transactor) repository.PasswordResetRepository {
+	return &passwordResetRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *passwordResetRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, token *model.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES (:user_id, :token_hash, :expires_at, NOW())
+		RETURNING id, created_at
+	`
+	args := map[string]any{
+		"user_id":    token.UserID,
+		"token_hash": token.TokenHash,
+		"expires_at": token.ExpiresAt,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if row.Next() {
+		err = row.Scan(&token.ID, &token.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan created password reset token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepository) Consume(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash = :token_hash AND used_at IS NULL AND expires_at > NOW()
+		RETURNING id, user_id, token_hash, expires_at, used_at, created_at
+	`
+	args := map[string]any{
+		"token_hash": tokenHash,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume password reset token: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrPasswordResetNotFound
+	}
+
+	err = row.StructScan(&token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *passwordResetRepository) InvalidateForUser(ctx context.Context, userID int64) error {
+	query := `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = :user_id AND used_at IS NULL`
+
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	return nil
+}
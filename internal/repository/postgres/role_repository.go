@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type roleRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewRoleRepository(db *sqlx.DB, transactor database.Transactor) repository.RoleRepository {
+	return &roleRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *roleRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *roleRepository) GetAll(ctx context.Context) ([]model.Role, error) {
+	var roles []model.Role
+	query := `SELECT id, name, description, created_at, updated_at FROM roles ORDER BY name ASC`
+
+	err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &roles, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	var role model.Role
+	query := `SELECT id, name, description, created_at, updated_at FROM roles WHERE name = :name`
+
+	args := map[string]any{
+		"name": name,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrRoleNotFound
+	}
+
+	err = row.StructScan(&role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan role: %w", err)
+	}
+
+	return &role, nil
+}
+
+func (r *roleRepository) GetAllPermissions(ctx context.Context) ([]string, error) {
+	query := `SELECT name FROM permissions ORDER BY name ASC`
+
+	return r.queryNames(ctx, query, map[string]any{})
+}
+
+func (r *roleRepository) GetPermissionsByRoleID(ctx context.Context, roleID int64) ([]string, error) {
+	query := `
+		SELECT p.name
+		FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		WHERE rp.role_id = :role_id
+		ORDER BY p.name ASC
+	`
+	args := map[string]any{
+		"role_id": roleID,
+	}
+
+	return r.queryNames(ctx, query, args)
+}
+
+func (r *roleRepository) GetUserRoles(ctx context.Context, userID int64) ([]model.Role, error) {
+	var roles []model.Role
+	query := `
+		SELECT r.id, r.name, r.description, r.created_at, r.updated_at
+		FROM roles r
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = :user_id
+		ORDER BY r.name ASC
+	`
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	defer rows.Close()
+
+	err = sqlx.StructScan(rows, &roles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) GetUserPermissions(ctx context.Context, userID int64) ([]string, error) {
+	query := `
+		SELECT DISTINCT p.name
+		FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		JOIN user_roles ur ON ur.role_id = rp.role_id
+		WHERE ur.user_id = :user_id
+		ORDER BY p.name ASC
+	`
+	args := map[string]any{
+		"user_id": userID,
+	}
+
+	return r.queryNames(ctx, query, args)
+}
+
+func (r *roleRepository) AssignToUser(ctx context.Context, userID, roleID int64) error {
+	query := `
+		INSERT INTO user_roles (user_id, role_id, created_at)
+		VALUES (:user_id, :role_id, NOW())
+		ON CONFLICT (user_id, role_id) DO NOTHING
+	`
+	args := map[string]any{
+		"user_id": userID,
+		"role_id": roleID,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *roleRepository) RemoveFromUser(ctx context.Context, userID, roleID int64) error {
+	query := `DELETE FROM user_roles WHERE user_id = :user_id AND role_id = :role_id`
+
+	args := map[string]any{
+		"user_id": userID,
+		"role_id": roleID,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to remove role: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrRoleNotAssigned
+	}
+
+	return nil
+}
+
+// queryNames runs a query returning a single text column
+func (r *roleRepository) queryNames(ctx context.Context, query string, args map[string]any) ([]string, error) {
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan permission: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
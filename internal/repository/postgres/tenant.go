@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	"go-gin-sqlx-template/pkg/tenant"
+)
+
+// userTenantCondition limits users to the members of an organization
+const userTenantCondition = "EXISTS (SELECT 1 FROM organization_members tm WHERE tm.user_id = users.id AND tm.organization_id = :tenant_organization_id)"
+
+// userTenantScope returns the condition limiting users to the organization ctx is scoped to
+// and adds its argument, or returns an empty string when ctx is not scoped.
+// Every query of the users table goes through it, QueryBuilder ignores the empty condition.
+func userTenantScope(ctx context.Context, args map[string]any) string {
+	organizationID, ok := tenant.OrganizationIDFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	args["tenant_organization_id"] = organizationID
+	return userTenantCondition
+}
+
+// andUserTenantScope is userTenantScope for appending to a WHERE clause
+func andUserTenantScope(ctx context.Context, args map[string]any) string {
+	if condition := userTenantScope(ctx, args); condition != "" {
+		return " AND " + condition
+	}
+	return ""
+}
+
+// withTenantMembership wraps an INSERT INTO users ... RETURNING query so the inserted users
+// join the organization ctx is scoped to in the same statement. The query is returned
+// unchanged when ctx is not scoped.
+func withTenantMembership(ctx context.Context, insertQuery, returning string, args map[string]any) string {
+	organizationID, ok := tenant.OrganizationIDFromContext(ctx)
+	if !ok {
+		return insertQuery
+	}
+	args["tenant_organization_id"] = organizationID
+	return `
+		WITH created AS (` + insertQuery + `),
+		membership AS (
+			INSERT INTO organization_members (organization_id, user_id, created_at)
+			SELECT :tenant_organization_id, id, NOW() FROM created
+		)
+		SELECT ` + returning + ` FROM created`
+}
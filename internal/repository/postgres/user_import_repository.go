@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/tenant"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type userImportRepository struct {
+	db         *sqlx.DB
+	transactor database.Transactor
+}
+
+func NewUserImportRepository(db *sqlx.DB, transactor database.Transactor) repository.UserImportRepository {
+	return &userImportRepository{
+		db:         db,
+		transactor: transactor,
+	}
+}
+
+// getExecutor returns the appropriate executor (DB or TX) from context
+func (r *userImportRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if r.transactor != nil {
+		return r.transactor.GetExecutor(ctx)
+	}
+	return r.db
+}
+
+func (r *userImportRepository) Create(ctx context.Context, userImport *model.UserImport) error {
+	query := `
+		INSERT INTO user_imports (status, format, filename, data, created_by, organization_id, created_at)
+		VALUES (:status, :format, :filename, :data, :created_by, :organization_id, NOW())
+		RETURNING id, created_at
+	`
+	args := map[string]any{
+		"status":          model.UserImportStatusPending,
+		"format":          userImport.Format,
+		"filename":        userImport.Filename,
+		"data":            userImport.Data,
+		"created_by":      userImport.CreatedBy,
+		"organization_id": userImport.OrganizationID,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to create user import: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if row.Next() {
+		err = row.Scan(&userImport.ID, &userImport.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan created user import: %w", err)
+		}
+	}
+	userImport.Status = model.UserImportStatusPending
+
+	return nil
+}
+
+func (r *userImportRepository) GetByID(ctx context.Context, id int64) (*model.UserImport, error) {
+	var userImport model.UserImport
+	query := `
+		SELECT id, status, format, filename, total_rows, processed_rows, created_rows, failed_rows,
+			errors, error, created_by, organization_id, created_at, started_at, finished_at
+		FROM user_imports
+		WHERE id = :id
+	`
+	args := map[string]any{
+		"id": id,
+	}
+	// Imports of other organizations are not visible to a scoped request
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		query += " AND organization_id = :organization_id"
+		args["organization_id"] = organizationID
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user import: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrUserImportNotFound
+	}
+
+	err = row.StructScan(&userImport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user import: %w", err)
+	}
+
+	return &userImport, nil
+}
+
+func (r *userImportRepository) Start(ctx context.Context, id int64) (*model.UserImport, error) {
+	var userImport model.UserImport
+	query := `
+		UPDATE user_imports
+		SET status = :status, started_at = COALESCE(started_at, NOW())
+		WHERE id = :id AND finished_at IS NULL
+		RETURNING id, status, format, filename, data, total_rows, processed_rows, created_rows, failed_rows,
+			errors, error, created_by, organization_id, created_at, started_at, finished_at
+	`
+	args := map[string]any{
+		"id":     id,
+		"status": model.UserImportStatusProcessing,
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start user import: %w", translateError(err))
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrUserImportFinished
+	}
+
+	err = row.StructScan(&userImport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user import: %w", err)
+	}
+
+	return &userImport, nil
+}
+
+func (r *userImportRepository) UpdateProgress(ctx context.Context, userImport *model.UserImport) error {
+	query := `
+		UPDATE user_imports
+		SET total_rows = :total_rows, processed_rows = :processed_rows, created_rows = :created_rows,
+			failed_rows = :failed_rows, errors = :errors
+		WHERE id = :id
+	`
+	args := map[string]any{
+		"id":             userImport.ID,
+		"total_rows":     userImport.TotalRows,
+		"processed_rows": userImport.ProcessedRows,
+		"created_rows":   userImport.CreatedRows,
+		"failed_rows":    userImport.FailedRows,
+		"errors":         userImport.Errors,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to update user import progress: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *userImportRepository) Finish(ctx context.Context, id int64, status string, errorMessage *string) error {
+	query := `
+		UPDATE user_imports
+		SET status = :status, error = :error, data = NULL, finished_at = NOW()
+		WHERE id = :id
+	`
+	args := map[string]any{
+		"id":     id,
+		"status": status,
+		"error":  errorMessage,
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to finish user import: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *userImportRepository) Expire(ctx context.Context, createdBefore time.Time, errorMessage string) (int64, error) {
+	query := `
+		UPDATE user_imports
+		SET status = :status, error = :error, data = NULL, finished_at = NOW()
+		WHERE finished_at IS NULL AND created_at < :created_before
+	`
+	args := map[string]any{
+		"status":         model.UserImportStatusFailed,
+		"error":          errorMessage,
+		"created_before": createdBefore,
+	}
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire user imports: %w", translateError(err))
+	}
+
+	return result.RowsAffected()
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"go-gin-sqlx-template/internal/model"
 	"go-gin-sqlx-template/internal/repository"
@@ -13,6 +15,9 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// userExportFetchSize is the number of rows Export reads from the cursor per round trip
+const userExportFetchSize = 500
+
 type userRepository struct {
 	db         *sqlx.DB
 	transactor database.Transactor
@@ -37,49 +42,114 @@ func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	query := `
 		INSERT INTO users (email, name, password, created_at, updated_at)
 		VALUES (:email, :name, :password, NOW(), NOW())
-		RETURNING id, created_at, updated_at
+		RETURNING id, version, created_at, updated_at
 	`
 	args := map[string]any{
 		"email":    user.Email,
 		"name":     user.Name,
 		"password": user.Password,
 	}
+	query = withTenantMembership(ctx, query, "id, version, created_at, updated_at", args)
 
 	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return fmt.Errorf("failed to create user: %w", translateError(err))
 	}
 	defer row.Close()
 
 	if row.Next() {
-		err = row.Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+		err = row.Scan(&user.ID, &user.Version, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return fmt.Errorf("failed to scan created user: %w", err)
 		}
 	}
+	// Violations may only surface while reading the result
+	if err := row.Err(); err != nil {
+		return fmt.Errorf("failed to create user: %w", translateError(err))
+	}
 
 	return nil
 }
 
+func (r *userRepository) CreateBatch(ctx context.Context, users []*model.User) ([]*model.User, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, len(users))
+	args := make(map[string]any, len(users)*3)
+	byEmail := make(map[string]*model.User, len(users))
+	for i, user := range users {
+		values[i] = fmt.Sprintf("(:email_%d, :name_%d, :password_%d, NOW(), NOW())", i, i, i)
+		args[fmt.Sprintf("email_%d", i)] = user.Email
+		args[fmt.Sprintf("name_%d", i)] = user.Name
+		args[fmt.Sprintf("password_%d", i)] = user.Password
+		byEmail[user.Email] = user
+	}
+
+	query := `
+		INSERT INTO users (email, name, password, created_at, updated_at)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT (email) WHERE deleted_at IS NULL DO NOTHING
+		RETURNING id, email, version, created_at, updated_at
+	`
+	query = withTenantMembership(ctx, query, "id, email, version, created_at, updated_at", args)
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create users: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	inserted := make(map[string]bool, len(users))
+	for rows.Next() {
+		var email string
+		var created model.User
+		if err := rows.Scan(&created.ID, &email, &created.Version, &created.CreatedAt, &created.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan created user: %w", err)
+		}
+
+		user := byEmail[email]
+		user.ID = created.ID
+		user.Version = created.Version
+		user.CreatedAt = created.CreatedAt
+		user.UpdatedAt = created.UpdatedAt
+		inserted[email] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read created users: %w", translateError(err))
+	}
+
+	var skipped []*model.User
+	for _, user := range users {
+		if !inserted[user.Email] {
+			skipped = append(skipped, user)
+		}
+	}
+
+	return skipped, nil
+}
+
 func (r *userRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
-	query := `SELECT id, email, name, created_at, updated_at FROM users WHERE id = :id`
+	query := `SELECT id, email, name, password, email_verified_at, version, avatar_key, avatar_url, avatar_thumbnails, created_at, updated_at FROM users WHERE id = :id AND deleted_at IS NULL`
 
 	args := map[string]any{
 		"id": id,
 	}
+	query += andUserTenantScope(ctx, args)
 
 	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, repository.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	defer row.Close()
 
 	if !row.Next() {
-		return nil, fmt.Errorf("user not found")
+		return nil, repository.ErrUserNotFound
 	}
 
 	err = row.StructScan(&user)
@@ -92,11 +162,12 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*model.User, er
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	query := `SELECT id, email, name, created_at, updated_at FROM users WHERE email = :email`
+	query := `SELECT id, email, name, password, email_verified_at, version, avatar_key, avatar_url, avatar_thumbnails, created_at, updated_at FROM users WHERE email = :email AND deleted_at IS NULL`
 
 	args := map[string]any{
 		"email": email,
 	}
+	query += andUserTenantScope(ctx, args)
 
 	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
 	if err != nil {
@@ -105,7 +176,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 	defer row.Close()
 
 	if !row.Next() {
-		return nil, fmt.Errorf("user not found")
+		return nil, repository.ErrUserNotFound
 	}
 
 	err = row.StructScan(&user)
@@ -124,17 +195,8 @@ func (r *userRepository) GetAll(ctx context.Context, pagination utils.Pagination
 		"offset": pagination.Offset,
 	}
 
-	qb := utils.NewQueryBuilder("SELECT id, email, name, created_at, updated_at FROM users")
-
-	if name, ok := filters.Get("name"); ok {
-		qb.AddWhere("name ILIKE :name")
-		args["name"] = "%" + name + "%"
-	}
-
-	if email, ok := filters.Get("email"); ok {
-		qb.AddWhere("email ILIKE :email")
-		args["email"] = "%" + email + "%"
-	}
+	qb := utils.NewQueryBuilder("SELECT id, email, name, email_verified_at, version, avatar_key, avatar_url, avatar_thumbnails, created_at, updated_at, deleted_at FROM users")
+	addUserFilters(ctx, qb, filters, args)
 
 	qb.SetOrderBy(sort)
 	qb.SetLimitOffset("LIMIT :limit", "OFFSET :offset")
@@ -155,32 +217,111 @@ func (r *userRepository) GetAll(ctx context.Context, pagination utils.Pagination
 	return users, nil
 }
 
-func (r *userRepository) Update(ctx context.Context, user *model.User) error {
-	query := `
-		UPDATE users 
-		SET email = :email, name = :name, updated_at = NOW()
-		WHERE id = :id
-		RETURNING updated_at
-	`
+// Export declares a cursor for the query and fetches userExportFetchSize rows at a time,
+// so memory use does not grow with the number of exported users
+func (r *userRepository) Export(ctx context.Context, filters utils.FilterParams, sort []utils.SortParams, limit int, fn func(user *model.User) error) error {
+	executor := r.getExecutor(ctx)
+	if _, ok := executor.(*sqlx.Tx); !ok {
+		return fmt.Errorf("user export must run in a transaction")
+	}
+
 	args := map[string]any{
-		"email": user.Email,
-		"name":  user.Name,
-		"id":    user.ID,
+		"limit": limit,
 	}
 
-	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	qb := utils.NewQueryBuilder("SELECT id, email, name, email_verified_at, version, avatar_key, avatar_url, avatar_thumbnails, created_at, updated_at, deleted_at FROM users")
+	addUserFilters(ctx, qb, filters, args)
+	qb.SetOrderBy(sort)
+	qb.SetLimitOffset("LIMIT :limit", "")
+
+	query := "DECLARE user_export NO SCROLL CURSOR FOR " + qb.Build()
+
+	_, err := sqlx.NamedExecContext(ctx, executor, query, database.SetMapSqlNamed(args))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return fmt.Errorf("user not found")
+		return fmt.Errorf("failed to declare user export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM user_export", userExportFetchSize)
+	for {
+		rows, err := executor.QueryxContext(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("failed to fetch users: %w", err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			var user model.User
+			if err := rows.StructScan(&user); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan user: %w", err)
+			}
+			fetched++
+
+			if err := fn(&user); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to fetch users: %w", err)
+		}
+		rows.Close()
+
+		if fetched < userExportFetchSize {
+			break
+		}
+	}
+
+	_, err = executor.ExecContext(ctx, "CLOSE user_export")
+	if err != nil {
+		return fmt.Errorf("failed to close user export cursor: %w", err)
+	}
+
+	return nil
+}
+
+// Update writes only the given columns of the user, see userColumnValues for the allowed ones.
+// The update only applies if the row is still at user.Version.
+func (r *userRepository) Update(ctx context.Context, user *model.User, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	values := userColumnValues(user)
+	args := map[string]any{
+		"id":      user.ID,
+		"version": user.Version,
+	}
+
+	setClauses := make([]string, 0, len(columns)+2)
+	for _, column := range columns {
+		value, ok := values[column]
+		if !ok {
+			return fmt.Errorf("column %s cannot be updated", column)
 		}
-		return fmt.Errorf("failed to update user: %w", err)
+		setClauses = append(setClauses, column+" = :"+column)
+		args[column] = value
 	}
+	setClauses = append(setClauses, "version = version + 1", "updated_at = NOW()")
+
+	query := `UPDATE users SET ` + strings.Join(setClauses, ", ") + ` WHERE id = :id AND version = :version AND deleted_at IS NULL` +
+		andUserTenantScope(ctx, args) + ` RETURNING version, updated_at`
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", translateError(err))
+	}
+	defer row.Close()
 
 	if !row.Next() {
-		return fmt.Errorf("user not found")
+		if err := row.Err(); err != nil {
+			return fmt.Errorf("failed to update user: %w", translateError(err))
+		}
+		return repository.ErrVersionConflict
 	}
 
-	err = row.StructScan(&user)
+	err = row.Scan(&user.Version, &user.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to scan updated user: %w", err)
 	}
@@ -188,16 +329,137 @@ func (r *userRepository) Update(ctx context.Context, user *model.User) error {
 	return nil
 }
 
-func (r *userRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM users WHERE id = :id`
+func (r *userRepository) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	query := `UPDATE users SET password = :password, updated_at = NOW() WHERE id = :id AND deleted_at IS NULL`
+
+	args := map[string]any{
+		"password": hashedPassword,
+		"id":       id,
+	}
+	query += andUserTenantScope(ctx, args)
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	query := `UPDATE users SET email_verified_at = NOW(), version = version + 1, updated_at = NOW() WHERE id = :id AND email_verified_at IS NULL AND deleted_at IS NULL`
+
+	args := map[string]any{
+		"id": id,
+	}
+	query += andUserTenantScope(ctx, args)
+
+	_, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to mark email as verified: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateAvatarThumbnails(ctx context.Context, id int64, avatarKey string, thumbnails model.AvatarThumbnails) (bool, error) {
+	query := `UPDATE users SET avatar_thumbnails = :avatar_thumbnails, version = version + 1, updated_at = NOW() WHERE id = :id AND avatar_key = :avatar_key AND deleted_at IS NULL`
+
+	args := map[string]any{
+		"id":                id,
+		"avatar_key":        avatarKey,
+		"avatar_thumbnails": thumbnails,
+	}
+	query += andUserTenantScope(ctx, args)
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return false, fmt.Errorf("failed to update avatar thumbnails: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete soft deletes the user if it is still at the given version.
+// The row is removed later by PurgeDeleted.
+func (r *userRepository) Delete(ctx context.Context, id int64, version int64) error {
+	query := `UPDATE users SET deleted_at = NOW(), version = version + 1, updated_at = NOW() WHERE id = :id AND version = :version AND deleted_at IS NULL`
+
+	args := map[string]any{
+		"id":      id,
+		"version": version,
+	}
+	query += andUserTenantScope(ctx, args)
+
+	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", translateError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetDeletedByID(ctx context.Context, id int64) (*model.User, error) {
+	var user model.User
+	query := `SELECT id, email, name, email_verified_at, version, avatar_key, avatar_url, avatar_thumbnails, created_at, updated_at, deleted_at FROM users WHERE id = :id AND deleted_at IS NOT NULL`
+
+	args := map[string]any{
+		"id": id,
+	}
+	query += andUserTenantScope(ctx, args)
+
+	row, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted user: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, repository.ErrUserNotFound
+	}
+
+	err = row.StructScan(&user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	query := `UPDATE users SET deleted_at = NULL, version = version + 1, updated_at = NOW() WHERE id = :id AND deleted_at IS NOT NULL`
 
 	args := map[string]any{
 		"id": id,
 	}
+	query += andUserTenantScope(ctx, args)
 
 	result, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return fmt.Errorf("failed to restore user: %w", translateError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -206,28 +468,46 @@ func (r *userRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return repository.ErrUserNotFound
 	}
 
 	return nil
 }
 
+func (r *userRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]int64, error) {
+	query := `DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < :deleted_before`
+
+	args := map[string]any{
+		"deleted_before": deletedBefore,
+	}
+	query += andUserTenantScope(ctx, args)
+	query += ` RETURNING id`
+
+	rows, err := sqlx.NamedQueryContext(ctx, r.getExecutor(ctx), query, database.SetMapSqlNamed(args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted users: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan purged user: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
 func (r *userRepository) Count(ctx context.Context, filters utils.FilterParams) (int64, error) {
 	var count int64
 
 	args := map[string]any{}
 
 	qb := utils.NewQueryBuilder("SELECT COUNT(*) FROM users")
-
-	if name, ok := filters.Get("name"); ok {
-		qb.AddWhere("name ILIKE :name")
-		args["name"] = "%" + name + "%"
-	}
-
-	if email, ok := filters.Get("email"); ok {
-		qb.AddWhere("email ILIKE :email")
-		args["email"] = "%" + email + "%"
-	}
+	addUserFilters(ctx, qb, filters, args)
 
 	query := qb.Build()
 
@@ -255,3 +535,39 @@ func (r *userRepository) Count(ctx context.Context, filters utils.FilterParams)
 
 	return count, nil
 }
+
+// addUserFilters applies the tenant scope of ctx and the name, email and include_deleted filters of the user listings
+func addUserFilters(ctx context.Context, qb *utils.QueryBuilder, filters utils.FilterParams, args map[string]any) {
+	qb.AddWhere(userTenantScope(ctx, args))
+	addDeletedFilter(qb, filters)
+
+	if name, ok := filters.Get("name"); ok {
+		qb.AddWhere("name ILIKE :name")
+		args["name"] = "%" + name + "%"
+	}
+
+	if email, ok := filters.Get("email"); ok {
+		qb.AddWhere("email ILIKE :email")
+		args["email"] = "%" + email + "%"
+	}
+}
+
+// addDeletedFilter hides soft-deleted users unless the include_deleted filter is set
+func addDeletedFilter(qb *utils.QueryBuilder, filters utils.FilterParams) {
+	if includeDeleted, ok := filters.Get("include_deleted"); ok && includeDeleted == "true" {
+		return
+	}
+	qb.AddWhere("deleted_at IS NULL")
+}
+
+// userColumnValues maps the columns Update is allowed to write to their values
+func userColumnValues(user *model.User) map[string]any {
+	return map[string]any{
+		"email":             user.Email,
+		"name":              user.Name,
+		"email_verified_at": user.EmailVerifiedAt,
+		"avatar_key":        user.AvatarKey,
+		"avatar_url":        user.AvatarURL,
+		"avatar_thumbnails": user.AvatarThumbnails,
+	}
+}
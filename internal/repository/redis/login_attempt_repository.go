@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresKeyPrefix = "login_failures:"
+	loginLockoutsKeyPrefix = "login_lockouts:"
+	loginLockKeyPrefix     = "login_lock:"
+)
+
+type loginAttemptRepository struct {
+	client *goredis.Client
+}
+
+func NewLoginAttemptRepository(redisClient *database.RedisClient) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		client: redisClient.Client,
+	}
+}
+
+func (r *loginAttemptRepository) IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	failuresKey := loginFailuresKeyPrefix + key
+
+	// The window slides: every failure keeps the counter alive for another window
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, failuresKey)
+	pipe.Expire(ctx, failuresKey, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to increment login failures: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *loginAttemptRepository) ResetFailures(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, loginFailuresKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) RecordLockout(ctx context.Context, key string, memory time.Duration) (int64, error) {
+	lockoutsKey := loginLockoutsKeyPrefix + key
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, lockoutsKey)
+	pipe.Expire(ctx, lockoutsKey, memory)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to record login lockout: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, duration time.Duration) error {
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, loginLockKeyPrefix+key, 1, duration)
+	pipe.Del(ctx, loginFailuresKeyPrefix+key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) LockRemaining(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, loginLockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get login lock: %w", err)
+	}
+	// PTTL returns negative values for missing keys and keys without expiry
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (r *loginAttemptRepository) Unlock(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, loginLockKeyPrefix+key, loginFailuresKeyPrefix+key, loginLockoutsKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to unlock login: %w", err)
+	}
+	return nil
+}
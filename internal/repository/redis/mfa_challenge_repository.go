@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const mfaChallengeKeyPrefix = "mfa_challenge:"
+
+// incrementAttemptsScript increments the attempts only if the challenge still
+// exists, so a late wrong code cannot recreate an expired challenge.
+// Returns the new attempts, or -1 when missing.
+var incrementAttemptsScript = goredis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+`)
+
+type mfaChallengeRepository struct {
+	client *goredis.Client
+}
+
+func NewMFAChallengeRepository(redisClient *database.RedisClient) repository.MFAChallengeRepository {
+	return &mfaChallengeRepository{
+		client: redisClient.Client,
+	}
+}
+
+func mfaChallengeKey(tokenHash string) string {
+	return mfaChallengeKeyPrefix + tokenHash
+}
+
+func (r *mfaChallengeRepository) Create(ctx context.Context, challenge *model.MFAChallenge) error {
+	key := mfaChallengeKey(challenge.TokenHash)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"user_id":    challenge.UserID,
+		"attempts":   challenge.Attempts,
+		"expires_at": challenge.ExpiresAt.Unix(),
+	})
+	pipe.ExpireAt(ctx, key, challenge.ExpiresAt)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create mfa challenge: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mfaChallengeRepository) Get(ctx context.Context, tokenHash string) (*model.MFAChallenge, error) {
+	values, err := r.client.HGetAll(ctx, mfaChallengeKey(tokenHash)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, repository.ErrMFAChallengeNotFound
+	}
+
+	userID, err := strconv.ParseInt(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mfa challenge user_id: %w", err)
+	}
+	attempts, _ := strconv.ParseInt(values["attempts"], 10, 64)
+
+	return &model.MFAChallenge{
+		TokenHash: tokenHash,
+		UserID:    userID,
+		Attempts:  attempts,
+		ExpiresAt: parseUnix(values["expires_at"]),
+	}, nil
+}
+
+func (r *mfaChallengeRepository) IncrementAttempts(ctx context.Context, tokenHash string) (int64, error) {
+	attempts, err := incrementAttemptsScript.Run(ctx, r.client, []string{mfaChallengeKey(tokenHash)}).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment mfa challenge attempts: %w", err)
+	}
+	if attempts < 0 {
+		return 0, repository.ErrMFAChallengeNotFound
+	}
+
+	return attempts, nil
+}
+
+func (r *mfaChallengeRepository) Delete(ctx context.Context, tokenHash string) (bool, error) {
+	deleted, err := r.client.Del(ctx, mfaChallengeKey(tokenHash)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+
+	return deleted > 0, nil
+}
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const oidcStateKeyPrefix = "oidc_state:"
+
+type oidcStateRepository struct {
+	client *goredis.Client
+}
+
+func NewOIDCStateRepository(redisClient *database.RedisClient) repository.OIDCStateRepository {
+	return &oidcStateRepository{
+		client: redisClient.Client,
+	}
+}
+
+func oidcStateKey(stateHash string) string {
+	return oidcStateKeyPrefix + stateHash
+}
+
+func (r *oidcStateRepository) Save(ctx context.Context, state *model.OIDCState) error {
+	key := oidcStateKey(state.StateHash)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"provider":      state.Provider,
+		"nonce":         state.Nonce,
+		"code_verifier": state.CodeVerifier,
+		"expires_at":    state.ExpiresAt.Unix(),
+	})
+	pipe.ExpireAt(ctx, key, state.ExpiresAt)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save oidc state: %w", err)
+	}
+
+	return nil
+}
+
+func (r *oidcStateRepository) Consume(ctx context.Context, stateHash string) (*model.OIDCState, error) {
+	key := oidcStateKey(stateHash)
+
+	pipe := r.client.TxPipeline()
+	get := pipe.HGetAll(ctx, key)
+	pipe.Del(ctx, key)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+
+	values := get.Val()
+	if len(values) == 0 {
+		return nil, repository.ErrOIDCStateNotFound
+	}
+
+	return &model.OIDCState{
+		StateHash:    stateHash,
+		Provider:     values["provider"],
+		Nonce:        values["nonce"],
+		CodeVerifier: values["code_verifier"],
+		ExpiresAt:    parseUnix(values["expires_at"]),
+	}, nil
+}
@@ -0,0 +1,308 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	refreshTokenKeyPrefix  = "refresh_token:"
+	refreshFamilyKeyPrefix = "refresh_family:"
+	userFamiliesKeyPrefix  = "user_refresh_families:"
+	sessionKeyPrefix       = "session:"
+)
+
+// markRotatedScript sets rotated_at only if the token still exists and has not
+// been rotated yet. Returns 1 when set, 0 when already rotated, -1 when missing.
+var markRotatedScript = goredis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+return redis.call("HSETNX", KEYS[1], "rotated_at", ARGV[1])
+`)
+
+// extendSessionScript moves the session expiry to the newest refresh token, but
+// never recreates a session that has been revoked in the meantime
+var extendSessionScript = goredis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], "expires_at", ARGV[1])
+redis.call("EXPIREAT", KEYS[1], ARGV[1])
+return 1
+`)
+
+// addUserSessionScript adds the session to the families of the user and extends
+// the set to the session, but never shortens it for the families already in it
+var addUserSessionScript = goredis.NewScript(`
+redis.call("SADD", KEYS[1], ARGV[1])
+if redis.call("TTL", KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call("EXPIREAT", KEYS[1], ARGV[3])
+end
+return 1
+`)
+
+// touchSessionScript updates last_seen_at if the session still exists.
+// Returns 1 when updated, 0 when the session is gone.
+var touchSessionScript = goredis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], "last_seen_at", ARGV[1])
+return 1
+`)
+
+type sessionRepository struct {
+	client *goredis.Client
+}
+
+func NewSessionRepository(redisClient *database.RedisClient) repository.SessionRepository {
+	return &sessionRepository{
+		client: redisClient.Client,
+	}
+}
+
+func refreshTokenKey(tokenHash string) string {
+	return refreshTokenKeyPrefix + tokenHash
+}
+
+func refreshFamilyKey(familyID string) string {
+	return refreshFamilyKeyPrefix + familyID
+}
+
+func userFamiliesKey(userID int64) string {
+	return userFamiliesKeyPrefix + strconv.FormatInt(userID, 10)
+}
+
+func sessionKey(sessionID string) string {
+	return sessionKeyPrefix + sessionID
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, session *model.Session) error {
+	key := sessionKey(session.ID)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"user_id":      session.UserID,
+		"user_agent":   session.UserAgent,
+		"ip_address":   session.IPAddress,
+		"created_at":   session.CreatedAt.Unix(),
+		"last_seen_at": session.LastSeenAt.Unix(),
+		"expires_at":   session.ExpiresAt.Unix(),
+	})
+	pipe.ExpireAt(ctx, key, session.ExpiresAt)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	err := addUserSessionScript.Run(ctx, r.client, []string{userFamiliesKey(session.UserID)},
+		session.ID, int64(time.Until(session.ExpiresAt).Seconds()), session.ExpiresAt.Unix()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add session to user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	values, err := r.client.HGetAll(ctx, sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, repository.ErrSessionNotFound
+	}
+
+	return parseSession(sessionID, values)
+}
+
+func (r *sessionRepository) ListSessions(ctx context.Context, userID int64) ([]model.Session, error) {
+	familyIDs, err := r.client.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user refresh token families: %w", err)
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*goredis.MapStringStringCmd, len(familyIDs))
+	for i, familyID := range familyIDs {
+		cmds[i] = pipe.HGetAll(ctx, sessionKey(familyID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	sessions := make([]model.Session, 0, len(familyIDs))
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		// Families revoked or expired since they were added to the set
+		if len(values) == 0 {
+			continue
+		}
+
+		session, err := parseSession(familyIDs[i], values)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) TouchSession(ctx context.Context, sessionID string, at time.Time) (bool, error) {
+	result, err := touchSessionScript.Run(ctx, r.client, []string{sessionKey(sessionID)}, at.Unix()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return result == 1, nil
+}
+
+func (r *sessionRepository) Save(ctx context.Context, token *model.RefreshToken) error {
+	key := refreshTokenKey(token.TokenHash)
+	familyKey := refreshFamilyKey(token.FamilyID)
+	userKey := userFamiliesKey(token.UserID)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"user_id":    token.UserID,
+		"family_id":  token.FamilyID,
+		"created_at": token.CreatedAt.Unix(),
+		"expires_at": token.ExpiresAt.Unix(),
+	})
+	pipe.ExpireAt(ctx, key, token.ExpiresAt)
+	pipe.SAdd(ctx, familyKey, token.TokenHash)
+	pipe.ExpireAt(ctx, familyKey, token.ExpiresAt)
+	pipe.SAdd(ctx, userKey, token.FamilyID)
+	pipe.ExpireAt(ctx, userKey, token.ExpiresAt)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	err := extendSessionScript.Run(ctx, r.client, []string{sessionKey(token.FamilyID)}, token.ExpiresAt.Unix()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) Get(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	values, err := r.client.HGetAll(ctx, refreshTokenKey(tokenHash)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, repository.ErrSessionNotFound
+	}
+
+	userID, err := strconv.ParseInt(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse refresh token user_id: %w", err)
+	}
+
+	token := &model.RefreshToken{
+		TokenHash: tokenHash,
+		UserID:    userID,
+		FamilyID:  values["family_id"],
+		CreatedAt: parseUnix(values["created_at"]),
+		ExpiresAt: parseUnix(values["expires_at"]),
+	}
+
+	if rotatedAt, ok := values["rotated_at"]; ok {
+		t := parseUnix(rotatedAt)
+		token.RotatedAt = &t
+	}
+
+	return token, nil
+}
+
+func (r *sessionRepository) MarkRotated(ctx context.Context, tokenHash string) (bool, error) {
+	result, err := markRotatedScript.Run(ctx, r.client, []string{refreshTokenKey(tokenHash)}, time.Now().Unix()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark refresh token as rotated: %w", err)
+	}
+
+	switch result {
+	case -1:
+		return false, repository.ErrSessionNotFound
+	case 0:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+func (r *sessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	familyKey := refreshFamilyKey(familyID)
+
+	hashes, err := r.client.SMembers(ctx, familyKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get refresh token family: %w", err)
+	}
+
+	keys := make([]string, 0, len(hashes)+1)
+	for _, hash := range hashes {
+		keys = append(keys, refreshTokenKey(hash))
+	}
+	keys = append(keys, familyKey, sessionKey(familyID))
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID int64) error {
+	userKey := userFamiliesKey(userID)
+
+	familyIDs, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get user refresh token families: %w", err)
+	}
+
+	for _, familyID := range familyIDs {
+		if err := r.RevokeFamily(ctx, familyID); err != nil {
+			return err
+		}
+	}
+
+	if err := r.client.Del(ctx, userKey).Err(); err != nil {
+		return fmt.Errorf("failed to revoke user refresh token families: %w", err)
+	}
+
+	return nil
+}
+
+func parseSession(sessionID string, values map[string]string) (*model.Session, error) {
+	userID, err := strconv.ParseInt(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse session user_id: %w", err)
+	}
+
+	return &model.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		UserAgent:  values["user_agent"],
+		IPAddress:  values["ip_address"],
+		CreatedAt:  parseUnix(values["created_at"]),
+		LastSeenAt: parseUnix(values["last_seen_at"]),
+		ExpiresAt:  parseUnix(values["expires_at"]),
+	}, nil
+}
+
+func parseUnix(value string) time.Time {
+	sec, _ := strconv.ParseInt(value, 10, 64)
+	return time.Unix(sec, 0)
+}
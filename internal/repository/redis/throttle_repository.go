@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const throttleKeyPrefix = "throttle:"
+
+type throttleRepository struct {
+	client *goredis.Client
+}
+
+func NewThrottleRepository(redisClient *database.RedisClient) repository.ThrottleRepository {
+	return &throttleRepository{
+		client: redisClient.Client,
+	}
+}
+
+func (r *throttleRepository) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, throttleKeyPrefix+key, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire throttle: %w", err)
+	}
+
+	return ok, nil
+}
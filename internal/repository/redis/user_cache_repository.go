@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/utils"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type userCacheRepository struct {
+	client *goredis.Client
+}
+
+// NewUserCacheRepository removes the responses stored by the cache middleware
+func NewUserCacheRepository(redisClient *database.RedisClient) repository.UserCacheRepository {
+	return &userCacheRepository{
+		client: redisClient.Client,
+	}
+}
+
+func (r *userCacheRepository) Invalidate(ctx context.Context, userID int64) error {
+	if err := r.client.Del(ctx, utils.UserCacheKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate user cache: %w", err)
+	}
+
+	return nil
+}
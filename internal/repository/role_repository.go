@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+)
+
+var (
+	// ErrRoleNotFound is returned when a role does not exist
+	ErrRoleNotFound = apperror.NotFound("role not found")
+	// ErrRoleNotAssigned is returned when removing a role the user does not have
+	ErrRoleNotAssigned = apperror.NotFound("role not assigned to user")
+)
+
+type RoleRepository interface {
+	GetAll(ctx context.Context) ([]model.Role, error)
+	GetByName(ctx context.Context, name string) (*model.Role, error)
+	GetAllPermissions(ctx context.Context) ([]string, error)
+	GetPermissionsByRoleID(ctx context.Context, roleID int64) ([]string, error)
+	GetUserRoles(ctx context.Context, userID int64) ([]model.Role, error)
+	GetUserPermissions(ctx context.Context, userID int64) ([]string, error)
+	AssignToUser(ctx context.Context, userID, roleID int64) error
+	RemoveFromUser(ctx context.Context, userID, roleID int64) error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"time"
+
+	"go-gin-sqlx-template/internal/apperror"
+)
+
+var (
+	// ErrSessionNotFound is returned when a refresh token does not exist or has expired
+	ErrSessionNotFound = apperror.NotFound("session not found")
+)
+
+type SessionRepository interface {
+	// CreateSession stores the device metadata of a new login until its ExpiresAt
+	// and adds it to the user, so sessions without refresh tokens are listed and revoked too
+	CreateSession(ctx context.Context, session *model.Session) error
+	// GetSession returns the session by its ID, which is the refresh token family ID
+	GetSession(ctx context.Context, sessionID string) (*model.Session, error)
+	// ListSessions returns every active session of the user
+	ListSessions(ctx context.Context, userID int64) ([]model.Session, error)
+	// TouchSession records activity on the session.
+	// It returns false if the session has been revoked or has expired.
+	TouchSession(ctx context.Context, sessionID string, at time.Time) (bool, error)
+	// Save stores the refresh token until its ExpiresAt and adds it to its family
+	// and the family to the user. The session of the family is extended to the same time.
+	Save(ctx context.Context, token *model.RefreshToken) error
+	// Get returns the refresh token by its hash
+	Get(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	// MarkRotated atomically flags the token as rotated.
+	// It returns false if the token had already been rotated.
+	MarkRotated(ctx context.Context, tokenHash string) (bool, error)
+	// RevokeFamily deletes every token belonging to the family and its session
+	RevokeFamily(ctx context.Context, familyID string) error
+	// RevokeAllForUser deletes every token family of the user
+	RevokeAllForUser(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type ThrottleRepository interface {
+	// Acquire reserves the key for the given duration.
+	// It returns false if the key is still reserved by an earlier call.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
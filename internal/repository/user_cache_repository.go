@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// UserCacheRepository removes the cached responses of users
+type UserCacheRepository interface {
+	// Invalidate removes the cached responses of the user.
+	// Call it once a change that bumps the version of the user is committed.
+	Invalidate(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"time"
+
+	"go-gin-sqlx-template/internal/apperror"
+)
+
+var (
+	// ErrUserImportNotFound is returned when a user import does not exist
+	ErrUserImportNotFound = apperror.NotFound("user import not found")
+	// ErrUserImportFinished is returned when starting an import that already completed or failed
+	ErrUserImportFinished = apperror.Conflict("user import already finished")
+)
+
+type UserImportRepository interface {
+	Create(ctx context.Context, userImport *model.UserImport) error
+	// GetByID returns the import without the uploaded file
+	GetByID(ctx context.Context, id int64) (*model.UserImport, error)
+	// Start marks a pending import as processing and returns it with the uploaded file.
+	// An import left processing by a crashed worker can be started again and resumes
+	// after its ProcessedRows.
+	Start(ctx context.Context, id int64) (*model.UserImport, error)
+	// UpdateProgress stores the row counters and errors
+	UpdateProgress(ctx context.Context, userImport *model.UserImport) error
+	// Finish sets the final status and error and drops the uploaded file
+	Finish(ctx context.Context, id int64, status string, errorMessage *string) error
+	// Expire fails the imports created before the time that did not finish, drops
+	// their files and returns how many there were
+	Expire(ctx context.Context, createdBefore time.Time, errorMessage string) (int64, error)
+}
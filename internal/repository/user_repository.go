@@ -2,16 +2,53 @@ package repository
 
 import (
 	"context"
+	"time"
+
+	"go-gin-sqlx-template/internal/apperror"
 	"go-gin-sqlx-template/internal/model"
 	"go-gin-sqlx-template/pkg/utils"
 )
 
+var (
+	// ErrUserNotFound is returned when a user does not exist, is deleted or is outside the tenant
+	ErrUserNotFound = apperror.NotFound("user not found")
+
+	// ErrVersionConflict is returned when a conditional write finds the user
+	// changed or deleted since it was read
+	ErrVersionConflict = apperror.Conflict("user was modified concurrently")
+)
+
+// ConstraintUserEmail is the unique index of the emails of active users. Create, Update
+// and Restore fail with a ConstraintError on it when the email is taken.
+const ConstraintUserEmail = "idx_users_email_active"
+
 type UserRepository interface {
 	Create(ctx context.Context, user *model.User) error
+	// CreateBatch inserts the users in one statement and fills in the ID, Version and
+	// timestamps of those inserted. Users whose email is taken by an active user are
+	// skipped and returned.
+	CreateBatch(ctx context.Context, users []*model.User) ([]*model.User, error)
 	GetByID(ctx context.Context, id int64) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
 	GetAll(ctx context.Context, pagination utils.PaginationParams, filters utils.FilterParams, sort []utils.SortParams) ([]model.User, error)
-	Update(ctx context.Context, user *model.User) error
-	Delete(ctx context.Context, id int64) error
+	// Export calls fn for each user matching the filters, in sort order and at most limit users.
+	// It reads through a server-side cursor and must run inside a transaction.
+	Export(ctx context.Context, filters utils.FilterParams, sort []utils.SortParams, limit int, fn func(user *model.User) error) error
+	// Update writes only the given columns if the row is still at user.Version,
+	// and refreshes user.Version and user.UpdatedAt
+	Update(ctx context.Context, user *model.User, columns []string) error
+	UpdatePassword(ctx context.Context, id int64, hashedPassword string) error
+	MarkEmailVerified(ctx context.Context, id int64) error
+	// UpdateAvatarThumbnails stores the thumbnails if the user still has the avatar they were generated from,
+	// it returns false when the avatar was replaced or the user deleted in the meantime
+	UpdateAvatarThumbnails(ctx context.Context, id int64, avatarKey string, thumbnails model.AvatarThumbnails) (bool, error)
+	// Delete soft deletes the user if it is still at the given version.
+	// Every other read and write ignores deleted users.
+	Delete(ctx context.Context, id int64, version int64) error
+	GetDeletedByID(ctx context.Context, id int64) (*model.User, error)
+	Restore(ctx context.Context, id int64) error
+	// PurgeDeleted permanently removes users soft deleted before the given time
+	// and returns their IDs
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]int64, error)
 	Count(ctx context.Context, filters utils.FilterParams) (int64, error)
 }
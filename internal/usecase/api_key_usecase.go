@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/pkg/auth"
+)
+
+type APIKeyUsecase interface {
+	CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error)
+	GetAllAPIKeys(ctx context.Context) ([]model.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, key string) (*auth.Claims, error)
+}
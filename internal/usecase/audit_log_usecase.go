@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/pkg/utils"
+)
+
+type AuditLogUsecase interface {
+	GetAuditLogs(ctx context.Context, pagination utils.PaginationParams, filters utils.FilterParams, sort []utils.SortParams) ([]model.AuditLogResponse, int64, error)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type AuthUsecase interface {
+	Login(ctx context.Context, req model.LoginRequest, client model.ClientInfo) (*model.LoginResponse, error)
+	VerifyMFA(ctx context.Context, req model.VerifyMFARequest, client model.ClientInfo) (*model.TokenResponse, error)
+	Refresh(ctx context.Context, refreshToken string, client model.ClientInfo) (*model.TokenResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest, client model.ClientInfo) error
+	ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, userID int64, req model.ChangePasswordRequest, client model.ClientInfo) (*model.TokenResponse, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, req model.ResendVerificationRequest) error
+	UnlockAccount(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type AvatarUsecase interface {
+	// UploadAvatar stores the image as the avatar of the user and enqueues the thumbnails
+	UploadAvatar(ctx context.Context, userID int64, data []byte) (*model.UserResponse, error)
+	// MaxBytes is the largest avatar UploadAvatar accepts
+	MaxBytes() int64
+	// GenerateThumbnails resizes the avatar into the thumbnail sizes, it is called by the worker
+	GenerateThumbnails(ctx context.Context, userID int64, avatarKey string) error
+}
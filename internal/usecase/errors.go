@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/internal/apperror"
+)
+
+var (
+	// ErrInvalidCredentials is returned when the email or password does not match
+	ErrInvalidCredentials = apperror.Unauthorized("invalid email or password")
+
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked
+	ErrInvalidRefreshToken = apperror.Unauthorized("invalid refresh token")
+
+	// ErrAccountLocked is returned when the account or the client IP is locked after
+	// too many failed logins. The returned error is an *AccountLockedError.
+	ErrAccountLocked = apperror.New(apperror.KindTooManyRequests, "too many failed login attempts")
+
+	// ErrRefreshTokenReused is returned when an already-rotated refresh token is presented again.
+	// The whole token family is revoked when this happens.
+	ErrRefreshTokenReused = apperror.Unauthorized("refresh token reuse detected")
+
+	// ErrRoleNotFound is returned when a role name does not exist
+	ErrRoleNotFound = apperror.NotFound("role not found")
+
+	// ErrRoleNotHeld is returned when assigning or removing a role with permissions the caller does not hold
+	ErrRoleNotHeld = apperror.Forbidden("cannot manage a role with permissions you do not hold")
+
+	// ErrInvalidAPIKey is returned when an API key is unknown, expired or revoked
+	ErrInvalidAPIKey = apperror.Unauthorized("invalid API key")
+
+	// ErrUnknownScope is returned when an API key is requested with a scope that is not a known permission
+	ErrUnknownScope = apperror.Validation("unknown scope")
+
+	// ErrScopeNotHeld is returned when an API key is requested with a scope the caller does not hold
+	ErrScopeNotHeld = apperror.Forbidden("cannot grant a scope you do not hold")
+
+	// ErrInvalidResetToken is returned when a password reset token is unknown, expired or already used
+	ErrInvalidResetToken = apperror.Validation("invalid or expired password reset token")
+
+	// ErrUserNotFound is returned when a user does not exist or is deleted
+	ErrUserNotFound = apperror.NotFound("user not found")
+
+	// ErrUserNotDeleted is returned when restoring a user that does not exist or is not deleted
+	ErrUserNotDeleted = apperror.NotFound("deleted user not found")
+
+	// ErrEmailTaken is returned when an email is already used by another active user
+	ErrEmailTaken = apperror.Conflict("email already exists")
+
+	// ErrPreconditionFailed is returned when If-Match does not match the current version of the resource
+	ErrPreconditionFailed = apperror.New(apperror.KindPreconditionFailed, "resource has been modified, fetch it again and retry")
+
+	// ErrPreconditionRequired is returned when If-Match is required but missing
+	ErrPreconditionRequired = apperror.New(apperror.KindPreconditionRequired, "the If-Match header is required")
+
+	// ErrInvalidPatch is returned when a merge patch is well-formed JSON but cannot be applied
+	ErrInvalidPatch = apperror.Validation("invalid merge patch")
+
+	// ErrInvalidCurrentPassword is returned when changing the password with a wrong current password
+	ErrInvalidCurrentPassword = apperror.Validation("current password is incorrect")
+
+	// ErrInvalidVerificationToken is returned when an email verification token is unknown, expired or already used
+	ErrInvalidVerificationToken = apperror.Validation("invalid or expired email verification token")
+
+	// ErrEmailNotVerified is returned on login when email verification is required and still pending
+	ErrEmailNotVerified = apperror.Forbidden("email address is not verified")
+
+	// ErrVerificationThrottled is returned when a verification email was resent too recently
+	ErrVerificationThrottled = apperror.New(apperror.KindTooManyRequests, "verification email was sent recently, wait before requesting another")
+
+	// ErrPasswordResetThrottled is returned when too many password resets were requested for the email or from the client IP
+	ErrPasswordResetThrottled = apperror.New(apperror.KindTooManyRequests, "too many password reset requests, wait before requesting another")
+
+	// ErrMFAAlreadyEnabled is returned when enrolling or confirming while two-factor authentication is already enabled
+	ErrMFAAlreadyEnabled = apperror.Conflict("two-factor authentication is already enabled")
+
+	// ErrMFANotEnrolled is returned when confirming without a pending enrollment
+	ErrMFANotEnrolled = apperror.NotFound("no pending two-factor authentication enrollment")
+
+	// ErrInvalidMFACode is returned when a TOTP or recovery code is wrong or was already used
+	ErrInvalidMFACode = apperror.Validation("invalid two-factor authentication code")
+
+	// ErrInvalidMFAChallenge is returned when a login challenge token is unknown, expired or exhausted
+	ErrInvalidMFAChallenge = apperror.Unauthorized("invalid or expired MFA challenge, log in again")
+
+	// ErrSessionNotFound is returned when a session does not exist or belongs to another user
+	ErrSessionNotFound = apperror.NotFound("session not found")
+
+	// ErrSessionRevoked is returned when an access token belongs to a revoked or expired session
+	ErrSessionRevoked = apperror.Unauthorized("session has been revoked")
+
+	// ErrUnknownProvider is returned when an OIDC provider name is not configured
+	ErrUnknownProvider = apperror.NotFound("unknown identity provider")
+
+	// ErrInvalidOIDCState is returned when an OIDC callback state is unknown, expired, already used or for another provider
+	ErrInvalidOIDCState = apperror.Validation("invalid or expired login state, try again")
+
+	// ErrProviderUnavailable is returned when the discovery document of an identity provider cannot be fetched
+	ErrProviderUnavailable = apperror.New(apperror.KindUnavailable, "identity provider is unavailable")
+
+	// ErrOIDCLoginFailed is returned when the code exchange or the ID token verification fails
+	ErrOIDCLoginFailed = apperror.Unauthorized("identity provider login failed")
+
+	// ErrOIDCEmailNotVerified is returned when an unlinked provider account has no verified email to link by
+	ErrOIDCEmailNotVerified = apperror.Forbidden("identity provider email is not verified")
+
+	// ErrOIDCAccountNotFound is returned when no user matches the provider account
+	ErrOIDCAccountNotFound = apperror.Forbidden("no account matches the identity provider account")
+
+	// ErrInvalidBatchOperation is returned when the data of a batch operation does not validate
+	ErrInvalidBatchOperation = apperror.Validation("invalid batch operation")
+
+	// ErrBatchRolledBack is returned for the operations of an atomic batch undone because another one failed.
+	// It only appears in batch results, never as the error of a request.
+	ErrBatchRolledBack = errors.New("batch rolled back")
+
+	// ErrUnsupportedImportFormat is returned when an import file is neither CSV nor NDJSON
+	ErrUnsupportedImportFormat = apperror.Validation("unsupported import format, use csv or ndjson")
+
+	// ErrInvalidImportFile is returned when an import file cannot be parsed or exceeds the row limit
+	ErrInvalidImportFile = apperror.Validation("invalid import file")
+
+	// ErrImportTooLarge is returned when an import file exceeds the configured size
+	ErrImportTooLarge = apperror.New(apperror.KindTooLarge, "import file is too large")
+
+	// ErrUserImportNotFound is returned when a user import does not exist
+	ErrUserImportNotFound = apperror.NotFound("user import not found")
+
+	// ErrAvatarTooLarge is returned when an avatar exceeds the configured size
+	ErrAvatarTooLarge = apperror.New(apperror.KindTooLarge, "avatar is too large")
+
+	// ErrUnsupportedAvatarType is returned when an avatar is not a JPEG, PNG or GIF image
+	ErrUnsupportedAvatarType = apperror.New(apperror.KindUnsupportedMediaType, "unsupported avatar type, use JPEG, PNG or GIF")
+
+	// ErrInvalidAvatar is returned when an avatar cannot be decoded or its dimensions are out of bounds
+	ErrInvalidAvatar = apperror.Validation("invalid avatar image")
+
+	// ErrImpersonationForbidden is returned when the caller may not impersonate the user: themselves,
+	// a user holding permissions the caller lacks, or while already impersonating
+	ErrImpersonationForbidden = apperror.Forbidden("impersonation of this user is not allowed")
+
+	// ErrNotImpersonating is returned when ending an impersonation with a regular token
+	ErrNotImpersonating = apperror.Validation("request is not made with an impersonation token")
+
+	// ErrOrganizationNotFound is returned when an organization does not exist
+	ErrOrganizationNotFound = apperror.NotFound("organization not found")
+
+	// ErrOrganizationRequired is returned when a request to tenant data selects no organization
+	// and the caller has none in their token
+	ErrOrganizationRequired = apperror.Forbidden("no organization selected")
+
+	// ErrNotOrganizationMember is returned when a request selects an organization the caller is not a member of
+	ErrNotOrganizationMember = apperror.Forbidden("not a member of the organization")
+
+	// ErrAlreadyOrganizationMember is returned when adding a user who already is a member of the organization
+	ErrAlreadyOrganizationMember = apperror.Conflict("user is already a member of the organization")
+
+	// ErrOrganizationMemberNotFound is returned when removing a user who is not a member of the organization
+	ErrOrganizationMemberNotFound = apperror.NotFound("user is not a member of the organization")
+
+	// ErrAPIKeyOrganizationRequired is returned when creating an API key without selecting its organization,
+	// only keys with the organizations:manage scope may act in every organization
+	ErrAPIKeyOrganizationRequired = apperror.Validation("select the organization of the API key with the X-Organization-ID header")
+)
+
+// AccountLockedError carries how long a locked login has to wait before retrying
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrAccountLocked, e.RetryAfter.Round(time.Second))
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type ImpersonationUsecase interface {
+	// StartImpersonation issues a short-lived token that lets the admin in ctx act as the user
+	StartImpersonation(ctx context.Context, userID int64, client model.ClientInfo) (*model.ImpersonationResponse, error)
+	// EndImpersonation revokes the impersonation token the request in ctx was made with
+	EndImpersonation(ctx context.Context) error
+}
@@ -0,0 +1,146 @@
+package impl
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"time"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/tenant"
+)
+
+type apiKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+	roleRepo   repository.RoleRepository
+	logger     *logger.Logger
+}
+
+func NewAPIKeyUsecase(
+	apiKeyRepo repository.APIKeyRepository,
+	roleRepo repository.RoleRepository,
+	log *logger.Logger,
+) usecase.APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+		roleRepo:   roleRepo,
+		logger:     log,
+	}
+}
+
+// CreateAPIKey only grants scopes the caller holds, so a key never reaches further than
+// its creator. Keys without an organization need organizations:manage, which in turn
+// only its holders can grant.
+func (u *apiKeyUsecase) CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, usecase.ErrScopeNotHeld
+	}
+
+	// Scopes are permission names, reject typos instead of issuing a useless key
+	permissions, err := u.roleRepo.GetAllPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(permissions, scope) {
+			return nil, apperror.Detail(usecase.ErrUnknownScope, scope)
+		}
+		if !claims.HasPermission(scope) {
+			return nil, apperror.Detail(usecase.ErrScopeNotHeld, scope)
+		}
+	}
+
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &model.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   auth.HashToken(key),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); ok && userID != 0 {
+		apiKey.CreatedBy = &userID
+	}
+
+	// The key acts in the organization it is created in, like the users it may read
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		apiKey.OrganizationID = &organizationID
+	} else if !slices.Contains(req.Scopes, permissionManageOrganizations) {
+		return nil, usecase.ErrAPIKeyOrganizationRequired
+	}
+
+	if err := u.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &model.CreateAPIKeyResponse{
+		APIKeyResponse: apiKey.ToResponse(),
+		Key:            key,
+	}, nil
+}
+
+func (u *apiKeyUsecase) GetAllAPIKeys(ctx context.Context) ([]model.APIKeyResponse, error) {
+	apiKeys, err := u.apiKeyRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		responses[i] = apiKey.ToResponse()
+	}
+
+	return responses, nil
+}
+
+func (u *apiKeyUsecase) RevokeAPIKey(ctx context.Context, id int64) error {
+	return u.apiKeyRepo.Revoke(ctx, id)
+}
+
+// Authenticate resolves an API key into claims whose permissions are the key's scopes
+func (u *apiKeyUsecase) Authenticate(ctx context.Context, key string) (*auth.Claims, error) {
+	prefix, ok := auth.APIKeyPrefix(key)
+	if !ok {
+		return nil, usecase.ErrInvalidAPIKey
+	}
+
+	apiKey, err := u.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, usecase.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(auth.HashToken(key))) != 1 {
+		return nil, usecase.ErrInvalidAPIKey
+	}
+
+	if !apiKey.IsActive(time.Now()) {
+		return nil, usecase.ErrInvalidAPIKey
+	}
+
+	if err := u.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+		u.logger.Errorf(ctx, "Failed to update api key last used: %v", err)
+	}
+
+	claims := &auth.Claims{
+		APIKeyID:    apiKey.ID,
+		Permissions: apiKey.Scopes,
+	}
+	if apiKey.OrganizationID != nil {
+		claims.OrganizationID = *apiKey.OrganizationID
+	}
+	return claims, nil
+}
@@ -0,0 +1,83 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/tenant"
+)
+
+// apiKeyRoleRepository knows the permissions API keys may be scoped to
+type apiKeyRoleRepository struct {
+	fakeRoleRepository
+}
+
+func (apiKeyRoleRepository) GetAllPermissions(ctx context.Context) ([]string, error) {
+	return []string{"users:read", "users:delete", "api_keys:manage", permissionManageOrganizations}, nil
+}
+
+type fakeAPIKeyRepository struct {
+	repository.APIKeyRepository
+
+	created []*model.APIKey
+}
+
+func (r *fakeAPIKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	apiKey.ID = int64(len(r.created) + 1)
+	r.created = append(r.created, apiKey)
+	return nil
+}
+
+func TestCreateAPIKeyScopes(t *testing.T) {
+	tenantAdmin := &auth.Claims{UserID: 1, Permissions: []string{"users:read", "api_keys:manage"}}
+	platformAdmin := &auth.Claims{UserID: 2, Permissions: []string{"users:read", "api_keys:manage", permissionManageOrganizations}}
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		scoped bool
+		scopes []string
+		want   error
+	}{
+		{"held scopes", tenantAdmin, true, []string{"users:read"}, nil},
+		{"unknown scope", tenantAdmin, true, []string{"users:raed"}, usecase.ErrUnknownScope},
+		{"scope the caller lacks", tenantAdmin, true, []string{"users:delete"}, usecase.ErrScopeNotHeld},
+		{"organizations:manage without holding it", tenantAdmin, true, []string{permissionManageOrganizations}, usecase.ErrScopeNotHeld},
+		{"no organization without organizations:manage", tenantAdmin, false, []string{"users:read"}, usecase.ErrAPIKeyOrganizationRequired},
+		{"no organization by a holder of organizations:manage", platformAdmin, false, []string{permissionManageOrganizations}, nil},
+		{"no caller", nil, true, []string{"users:read"}, usecase.ErrScopeNotHeld},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyRepo := &fakeAPIKeyRepository{}
+			u := NewAPIKeyUsecase(apiKeyRepo, apiKeyRoleRepository{}, testLogger)
+
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.WithClaims(ctx, tt.claims)
+			}
+			if tt.scoped {
+				ctx = tenant.WithOrganizationID(ctx, 7)
+			}
+
+			resp, err := u.CreateAPIKey(ctx, model.CreateAPIKeyRequest{Name: "ci", Scopes: tt.scopes})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("CreateAPIKey = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(apiKeyRepo.created) != 0 {
+					t.Error("rejected key was stored")
+				}
+				return
+			}
+			if resp.Key == "" || len(apiKeyRepo.created) != 1 {
+				t.Fatalf("CreateAPIKey = %+v, want a stored key", resp)
+			}
+		})
+	}
+}
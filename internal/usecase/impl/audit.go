@@ -0,0 +1,144 @@
+package impl
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/tenant"
+	"go-gin-sqlx-template/pkg/utils"
+)
+
+// auditBatchSize is the number of audit logs recordUsers inserts per statement
+const auditBatchSize = 1000
+
+// auditRecorder writes audit logs with the actor and request taken from the context.
+// It is shared by every usecase that changes users.
+type auditRecorder struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+func newAuditRecorder(auditLogRepo repository.AuditLogRepository) *auditRecorder {
+	return &auditRecorder{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// record writes an audit log of the change. Call it with the transaction context
+// of the change so both are committed or rolled back together.
+func (r *auditRecorder) record(ctx context.Context, action, entityType string, entityID int64, changes model.AuditChanges) error {
+	return r.auditLogRepo.Create(ctx, newAuditLog(ctx, action, entityType, entityID, changes))
+}
+
+// recordUsers writes one audit log of the action per user, for changes made to many users at once.
+// The logs are inserted in chunks to stay below the bind parameter limit of Postgres.
+func (r *auditRecorder) recordUsers(ctx context.Context, action string, userIDs []int64) error {
+	for chunk := range slices.Chunk(userIDs, auditBatchSize) {
+		logs := make([]*model.AuditLog, len(chunk))
+		for i, userID := range chunk {
+			logs[i] = newAuditLog(ctx, action, model.AuditEntityUser, userID, nil)
+		}
+		if err := r.auditLogRepo.CreateBatch(ctx, logs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordUser writes an audit log of a user change with the fields that differ between the snapshots
+func (r *auditRecorder) recordUser(ctx context.Context, action string, userID int64, before, after map[string]any) error {
+	return r.record(ctx, action, model.AuditEntityUser, userID, auditDiff(before, after))
+}
+
+// newAuditLog builds an audit log attributed to the caller of the request in ctx and
+// to the organization ctx is scoped to. Changes made with an impersonation token also
+// record the admin behind it.
+func newAuditLog(ctx context.Context, action, entityType string, entityID int64, changes model.AuditChanges) *model.AuditLog {
+	log := &model.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+	}
+
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		if claims.UserID != 0 {
+			log.ActorUserID = &claims.UserID
+		}
+		if claims.APIKeyID != 0 {
+			log.ActorAPIKeyID = &claims.APIKeyID
+		}
+		if claims.ImpersonatorID != 0 {
+			log.ImpersonatorUserID = &claims.ImpersonatorID
+		}
+	}
+
+	// Changes to an organization belong to it even when made outside of its scope
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		log.OrganizationID = &organizationID
+	} else if entityType == model.AuditEntityOrganization {
+		log.OrganizationID = &entityID
+	}
+
+	if info, ok := utils.RequestInfoFromContext(ctx); ok {
+		if info.RequestID != "" {
+			log.RequestID = &info.RequestID
+		}
+		if info.IPAddress != "" {
+			log.IPAddress = &info.IPAddress
+		}
+	}
+
+	return log
+}
+
+// userAuditFields returns the audited fields of the user, nil for no user.
+// The password hash is never audited, password changes are recorded as actions.
+func userAuditFields(user *model.User) map[string]any {
+	if user == nil {
+		return nil
+	}
+	return map[string]any{
+		"email":             user.Email,
+		"name":              user.Name,
+		"email_verified_at": auditTime(user.EmailVerifiedAt),
+		"deleted_at":        auditTime(user.DeletedAt),
+		"avatar_url":        auditString(user.AvatarURL),
+	}
+}
+
+// auditString dereferences an optional string so snapshots compare by value
+func auditString(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// auditTime formats an optional time so snapshots compare by value
+func auditTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// auditDiff returns the fields whose value differs between the snapshots.
+// A nil snapshot stands for an entity that does not exist.
+func auditDiff(before, after map[string]any) model.AuditChanges {
+	changes := model.AuditChanges{}
+	for field, value := range after {
+		if before[field] != value {
+			changes[field] = model.AuditChange{Before: before[field], After: value}
+		}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok && value != nil {
+			changes[field] = model.AuditChange{Before: value}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,58 @@
+package impl
+
+import (
+	"context"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type auditLogUsecase struct {
+	auditLogRepo repository.AuditLogRepository
+	logger       *logger.Logger
+}
+
+func NewAuditLogUsecase(auditLogRepo repository.AuditLogRepository, log *logger.Logger) usecase.AuditLogUsecase {
+	return &auditLogUsecase{
+		auditLogRepo: auditLogRepo,
+		logger:       log,
+	}
+}
+
+func (u *auditLogUsecase) GetAuditLogs(ctx context.Context, pagination utils.PaginationParams, filters utils.FilterParams, sort []utils.SortParams) ([]model.AuditLogResponse, int64, error) {
+	var (
+		logs  []model.AuditLog
+		total int64
+	)
+
+	// Run GetAll and Count concurrently
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		logs, err = u.auditLogRepo.GetAll(ctx, pagination, filters, sort)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		total, err = u.auditLogRepo.Count(ctx, filters)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.AuditLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = log.ToResponse()
+	}
+
+	return responses, total, nil
+}
@@ -0,0 +1,647 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/internal/worker"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultPasswordResetTokenTTL           = 1 * time.Hour
+	defaultEmailVerificationResendInterval = 1 * time.Minute
+	defaultLoginMaxFailures                = 5
+	defaultLoginMaxFailuresPerIP           = 20
+	defaultLoginFailureWindow              = 15 * time.Minute
+	defaultLoginLockoutBase                = 1 * time.Minute
+	defaultLoginLockoutMax                 = 1 * time.Hour
+	// mfaMaxAttempts is how many wrong codes a login challenge accepts before it is discarded
+	mfaMaxAttempts = 5
+	// loginLockoutMemory is how long lockouts are remembered for escalating the next one
+	loginLockoutMemory = 24 * time.Hour
+)
+
+type authUsecase struct {
+	userRepo              repository.UserRepository
+	sessionRepo           repository.SessionRepository
+	passwordResetRepo     repository.PasswordResetRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	throttleRepo          repository.ThrottleRepository
+	loginAttemptRepo      repository.LoginAttemptRepository
+	mfaChallengeRepo      repository.MFAChallengeRepository
+	txManager             database.Transactor
+	asynqClient           *asynq.Client
+	verificationSender    *emailVerificationSender
+	audit                 *auditRecorder
+	cache                 *userCache
+	mfaVerifier           *mfaVerifier
+	tokenIssuer           *tokenIssuer
+	// dummyPasswordHash is compared against when the user does not exist so that
+	// login takes roughly the same time whether or not the email is registered
+	dummyPasswordHash []byte
+	config            config.Config
+	logger            *logger.Logger
+}
+
+func NewAuthUsecase(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	organizationRepo repository.OrganizationRepository,
+	sessionRepo repository.SessionRepository,
+	passwordResetRepo repository.PasswordResetRepository,
+	emailVerificationRepo repository.EmailVerificationRepository,
+	throttleRepo repository.ThrottleRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	mfaRepo repository.MFARepository,
+	mfaChallengeRepo repository.MFAChallengeRepository,
+	auditLogRepo repository.AuditLogRepository,
+	userCacheRepo repository.UserCacheRepository,
+	txManager database.Transactor,
+	jwtManager *auth.JWTManager,
+	asynqClient *asynq.Client,
+	cfg config.Config,
+	log *logger.Logger,
+) (usecase.AuthUsecase, error) {
+	dummyPasswordHash, err := bcrypt.GenerateFromPassword([]byte("dummy-password"), passwordCost(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash dummy password: %w", err)
+	}
+
+	return &authUsecase{
+		userRepo:              userRepo,
+		sessionRepo:           sessionRepo,
+		passwordResetRepo:     passwordResetRepo,
+		emailVerificationRepo: emailVerificationRepo,
+		throttleRepo:          throttleRepo,
+		loginAttemptRepo:      loginAttemptRepo,
+		mfaChallengeRepo:      mfaChallengeRepo,
+		txManager:             txManager,
+		asynqClient:           asynqClient,
+		verificationSender:    newEmailVerificationSender(emailVerificationRepo, asynqClient, cfg, log),
+		audit:                 newAuditRecorder(auditLogRepo),
+		cache:                 newUserCache(userCacheRepo, log),
+		mfaVerifier:           newMFAVerifier(mfaRepo),
+		tokenIssuer:           newTokenIssuer(roleRepo, organizationRepo, sessionRepo, mfaRepo, mfaChallengeRepo, jwtManager, cfg),
+		dummyPasswordHash:     dummyPasswordHash,
+		config:                cfg,
+		logger:                log,
+	}, nil
+}
+
+// Login checks the password and issues a token pair. For accounts with 2FA enabled
+// it issues a short-lived MFA challenge instead, to be redeemed with VerifyMFA.
+func (u *authUsecase) Login(ctx context.Context, req model.LoginRequest, client model.ClientInfo) (*model.LoginResponse, error) {
+	// Locked callers are rejected before spending any time on bcrypt
+	if err := u.checkLoginLock(ctx, req.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			return nil, err
+		}
+		u.logger.Infof(ctx, "login failed for %s: %v", req.Email, err)
+		_ = bcrypt.CompareHashAndPassword(u.dummyPasswordHash, []byte(req.Password))
+		// Unknown emails count too, so lockouts do not reveal which accounts exist
+		u.recordLoginFailure(ctx, req.Email, client.IPAddress)
+		return nil, usecase.ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		u.recordLoginFailure(ctx, req.Email, client.IPAddress)
+		return nil, usecase.ErrInvalidCredentials
+	}
+
+	if err := u.loginAttemptRepo.ResetFailures(ctx, accountLoginKey(req.Email)); err != nil {
+		u.logger.Errorf(ctx, "Failed to reset login failures: %v", err)
+	}
+
+	// Upgrade hashes created before the configured cost was raised, the plain
+	// password is only available here
+	if needsRehash(u.config, user.Password) {
+		if hashedPassword, err := hashPassword(u.config, req.Password); err != nil {
+			u.logger.Errorf(ctx, "Failed to rehash password: %v", err)
+		} else if err := u.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+			u.logger.Errorf(ctx, "Failed to store rehashed password: %v", err)
+		}
+	}
+
+	// Checked after the password so the error does not reveal which emails are pending
+	if u.config.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		return nil, usecase.ErrEmailNotVerified
+	}
+
+	return u.tokenIssuer.completeLogin(ctx, user, client)
+}
+
+// VerifyMFA redeems a login challenge with a TOTP or recovery code and issues a token pair.
+// A challenge is discarded after mfaMaxAttempts wrong codes, the user then has to log in again.
+func (u *authUsecase) VerifyMFA(ctx context.Context, req model.VerifyMFARequest, client model.ClientInfo) (*model.TokenResponse, error) {
+	tokenHash := auth.HashToken(req.MFAToken)
+
+	challenge, err := u.mfaChallengeRepo.Get(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFAChallengeNotFound) {
+			return nil, usecase.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+
+	if err := u.mfaVerifier.verify(ctx, challenge.UserID, req.Code); err != nil {
+		if !errors.Is(err, usecase.ErrInvalidMFACode) {
+			return nil, err
+		}
+
+		attempts, incErr := u.mfaChallengeRepo.IncrementAttempts(ctx, tokenHash)
+		if incErr != nil {
+			if errors.Is(incErr, repository.ErrMFAChallengeNotFound) {
+				return nil, usecase.ErrInvalidMFAChallenge
+			}
+			return nil, incErr
+		}
+		if attempts >= mfaMaxAttempts {
+			if _, delErr := u.mfaChallengeRepo.Delete(ctx, tokenHash); delErr != nil {
+				u.logger.Errorf(ctx, "Failed to delete mfa challenge: %v", delErr)
+			}
+		}
+		// A wrong code fails the login, unlike a wrong code when managing two-factor authentication
+		return nil, apperror.Wrap(err, apperror.KindUnauthorized, usecase.ErrInvalidMFACode.Message)
+	}
+
+	// Only the first caller may redeem the challenge
+	deleted, err := u.mfaChallengeRepo.Delete(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, usecase.ErrInvalidMFAChallenge
+	}
+
+	user, err := u.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, usecase.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+
+	return u.tokenIssuer.startSession(ctx, user, client)
+}
+
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string, client model.ClientInfo) (*model.TokenResponse, error) {
+	tokenHash := auth.HashToken(refreshToken)
+
+	stored, err := u.sessionRepo.Get(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return nil, usecase.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	// Only the first caller may rotate a token. Anyone presenting it again is
+	// either an attacker or a client holding a stolen copy, so kill the family.
+	rotated, err := u.sessionRepo.MarkRotated(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return nil, usecase.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !rotated {
+		u.logger.Warnf(ctx, "refresh token reuse detected for user %d, revoking family %s", stored.UserID, stored.FamilyID)
+		if err := u.sessionRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			u.logger.Errorf(ctx, "Failed to revoke refresh token family: %v", err)
+		}
+		return nil, usecase.ErrRefreshTokenReused
+	}
+
+	user, err := u.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if revokeErr := u.sessionRepo.RevokeFamily(ctx, stored.FamilyID); revokeErr != nil {
+			u.logger.Errorf(ctx, "Failed to revoke refresh token family: %v", revokeErr)
+		}
+		return nil, usecase.ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	touched, err := u.sessionRepo.TouchSession(ctx, stored.FamilyID, now)
+	if err != nil {
+		return nil, err
+	}
+	// Families created before sessions were tracked get one on their first refresh
+	if !touched {
+		err = u.sessionRepo.CreateSession(ctx, &model.Session{
+			ID:         stored.FamilyID,
+			UserID:     user.ID,
+			UserAgent:  client.UserAgent,
+			IPAddress:  client.IPAddress,
+			CreatedAt:  stored.CreatedAt,
+			LastSeenAt: now,
+			ExpiresAt:  stored.ExpiresAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return u.tokenIssuer.issueTokens(ctx, user, stored.FamilyID)
+}
+
+func (u *authUsecase) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := u.sessionRepo.Get(ctx, auth.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return usecase.ErrInvalidRefreshToken
+		}
+		return err
+	}
+
+	return u.sessionRepo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+// ForgotPassword emails a single-use reset link if the account exists.
+// Requests are limited per email address and client IP like failed logins, and the limit
+// applies before the lookup. Every later failure is only logged, so neither the response
+// nor its status reveals whether the email is registered.
+func (u *authUsecase) ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest, client model.ClientInfo) error {
+	if err := u.limitPasswordResetRequests(ctx, req.Email, client.IPAddress); err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		u.logger.Infof(ctx, "password reset requested for unknown email: %v", err)
+		return nil
+	}
+
+	if err := u.sendPasswordReset(ctx, user); err != nil {
+		u.logger.Errorf(ctx, "Failed to send password reset: %v", err)
+	}
+	return nil
+}
+
+// limitPasswordResetRequests counts the request against the email address and the client IP
+// with the thresholds and lockouts of failed logins, but on keys of their own so reset requests
+// never lock anyone out of logging in
+func (u *authUsecase) limitPasswordResetRequests(ctx context.Context, email, ip string) error {
+	maxRequests := u.config.LoginMaxFailures
+	if maxRequests <= 0 {
+		maxRequests = defaultLoginMaxFailures
+	}
+	maxRequestsPerIP := u.config.LoginMaxFailuresPerIP
+	if maxRequestsPerIP <= 0 {
+		maxRequestsPerIP = defaultLoginMaxFailuresPerIP
+	}
+
+	limits := []struct {
+		key         string
+		maxRequests int64
+	}{
+		{passwordResetKey(accountLoginKey(email)), maxRequests},
+		{passwordResetKey(ipLoginKey(ip)), maxRequestsPerIP},
+	}
+	for _, limit := range limits {
+		remaining, err := u.loginAttemptRepo.LockRemaining(ctx, limit.key)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return usecase.ErrPasswordResetThrottled
+		}
+	}
+
+	for _, limit := range limits {
+		if _, err := u.registerLoginFailure(ctx, limit.key, limit.maxRequests); err != nil {
+			u.logger.Errorf(ctx, "Failed to record password reset request: %v", err)
+		}
+	}
+	return nil
+}
+
+func passwordResetKey(loginKey string) string {
+	return "password_reset:" + loginKey
+}
+
+// sendPasswordReset stores a new reset token of the user and enqueues the email with its link
+func (u *authUsecase) sendPasswordReset(ctx context.Context, user *model.User) error {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	ttl := u.config.PasswordResetTokenTTL
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTokenTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	err = u.passwordResetRepo.Create(ctx, &model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	resetURL := fmt.Sprintf("%s?token=%s", u.config.PasswordResetURL, token)
+	task, err := worker.NewPasswordResetEmailTask(ctx, user.Email, user.Name, resetURL, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset task: %w", err)
+	}
+
+	info, err := u.asynqClient.Enqueue(task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue password reset task: %w", err)
+	}
+	u.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
+	return nil
+}
+
+// ResetPassword consumes the reset token and sets the new password.
+// Every other outstanding reset token and every session of the user is invalidated as well.
+func (u *authUsecase) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error {
+	hashedPassword, err := hashPassword(u.config, req.Password)
+	if err != nil {
+		return err
+	}
+
+	var userID int64
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		token, err := u.passwordResetRepo.Consume(txCtx, auth.HashToken(req.Token))
+		if err != nil {
+			if errors.Is(err, repository.ErrPasswordResetNotFound) {
+				return usecase.ErrInvalidResetToken
+			}
+			return err
+		}
+		userID = token.UserID
+
+		if err := u.userRepo.UpdatePassword(txCtx, token.UserID, hashedPassword); err != nil {
+			return err
+		}
+
+		if err := u.passwordResetRepo.InvalidateForUser(txCtx, token.UserID); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserPasswordReset, model.AuditEntityUser, token.UserID, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.sessionRepo.RevokeAllForUser(ctx, userID)
+}
+
+// ChangePassword sets a new password after checking the current one.
+// Every session of the user is revoked and a fresh token pair is returned for the caller.
+func (u *authUsecase) ChangePassword(ctx context.Context, userID int64, req model.ChangePasswordRequest, client model.ClientInfo) (*model.TokenResponse, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return nil, usecase.ErrInvalidCurrentPassword
+	}
+
+	hashedPassword, err := hashPassword(u.config, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.userRepo.UpdatePassword(txCtx, user.ID, hashedPassword); err != nil {
+			return err
+		}
+
+		// Reset links sent before the change must not be able to override it
+		if err := u.passwordResetRepo.InvalidateForUser(txCtx, user.ID); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserPasswordChanged, model.AuditEntityUser, user.ID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.sessionRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return u.tokenIssuer.startSession(ctx, user, client)
+}
+
+// VerifyEmail consumes the verification token and marks the email as verified.
+// Every other outstanding verification token of the user is invalidated as well.
+func (u *authUsecase) VerifyEmail(ctx context.Context, token string) error {
+	var userID int64
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		verification, err := u.emailVerificationRepo.Consume(txCtx, auth.HashToken(token))
+		if err != nil {
+			if errors.Is(err, repository.ErrEmailVerificationNotFound) {
+				return usecase.ErrInvalidVerificationToken
+			}
+			return err
+		}
+
+		userID = verification.UserID
+
+		if err := u.userRepo.MarkEmailVerified(txCtx, verification.UserID); err != nil {
+			return err
+		}
+
+		if err := u.emailVerificationRepo.InvalidateForUser(txCtx, verification.UserID); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserEmailVerified, model.AuditEntityUser, verification.UserID, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.cache.invalidate(ctx, userID)
+	return nil
+}
+
+// ResendVerification emails a new verification link if the account exists and is not verified yet.
+// Resends are throttled per email address, and the throttle applies before the lookup
+// so the response does not reveal whether the email is registered.
+func (u *authUsecase) ResendVerification(ctx context.Context, req model.ResendVerificationRequest) error {
+	interval := u.config.EmailVerificationResendInterval
+	if interval <= 0 {
+		interval = defaultEmailVerificationResendInterval
+	}
+
+	acquired, err := u.throttleRepo.Acquire(ctx, "email_verification:"+auth.HashToken(strings.ToLower(req.Email)), interval)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return usecase.ErrVerificationThrottled
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		u.logger.Infof(ctx, "verification resend requested for unknown email: %v", err)
+		return nil
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return u.verificationSender.send(ctx, user)
+}
+
+// UnlockAccount lifts a lockout of the user's account and forgets its failed attempts
+func (u *authUsecase) UnlockAccount(ctx context.Context, userID int64) error {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Lockouts live in Redis, the audit log is rolled back if unlocking fails
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.audit.record(txCtx, model.AuditActionUserUnlocked, model.AuditEntityUser, user.ID, nil); err != nil {
+			return err
+		}
+
+		return u.loginAttemptRepo.Unlock(txCtx, accountLoginKey(user.Email))
+	})
+}
+
+func accountLoginKey(email string) string {
+	return "account:" + strings.ToLower(email)
+}
+
+func ipLoginKey(ip string) string {
+	return "ip:" + ip
+}
+
+// checkLoginLock returns an *usecase.AccountLockedError if the account or the client IP is locked
+func (u *authUsecase) checkLoginLock(ctx context.Context, email, ip string) error {
+	for _, key := range []string{accountLoginKey(email), ipLoginKey(ip)} {
+		remaining, err := u.loginAttemptRepo.LockRemaining(ctx, key)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return &usecase.AccountLockedError{RetryAfter: remaining}
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login against the account and the client IP.
+// Errors are only logged, failing the login because Redis is down would lock everyone out.
+func (u *authUsecase) recordLoginFailure(ctx context.Context, email, ip string) {
+	maxFailures := u.config.LoginMaxFailures
+	if maxFailures <= 0 {
+		maxFailures = defaultLoginMaxFailures
+	}
+	maxFailuresPerIP := u.config.LoginMaxFailuresPerIP
+	if maxFailuresPerIP <= 0 {
+		maxFailuresPerIP = defaultLoginMaxFailuresPerIP
+	}
+
+	lockout, err := u.registerLoginFailure(ctx, accountLoginKey(email), maxFailures)
+	if err != nil {
+		u.logger.Errorf(ctx, "Failed to record login failure: %v", err)
+	} else if lockout > 0 {
+		u.logger.Warnf(ctx, "account %s locked for %s after %d failed logins", email, lockout, maxFailures)
+		u.sendLockoutAlert(ctx, fmt.Sprintf("Account locked: %s for %s after %d failed logins, last from %s", email, lockout, maxFailures, ip))
+	}
+
+	lockout, err = u.registerLoginFailure(ctx, ipLoginKey(ip), maxFailuresPerIP)
+	if err != nil {
+		u.logger.Errorf(ctx, "Failed to record login failure: %v", err)
+	} else if lockout > 0 {
+		u.logger.Warnf(ctx, "ip %s locked for %s after %d failed logins", ip, lockout, maxFailuresPerIP)
+	}
+}
+
+// registerLoginFailure increments the failures of the key and locks it once it reaches
+// maxFailures. Every lockout within loginLockoutMemory doubles the previous duration.
+// It returns the lockout duration, or zero if the key was not locked.
+func (u *authUsecase) registerLoginFailure(ctx context.Context, key string, maxFailures int64) (time.Duration, error) {
+	window := u.config.LoginFailureWindow
+	if window <= 0 {
+		window = defaultLoginFailureWindow
+	}
+
+	failures, err := u.loginAttemptRepo.IncrementFailures(ctx, key, window)
+	if err != nil {
+		return 0, err
+	}
+	if failures < maxFailures {
+		return 0, nil
+	}
+
+	lockouts, err := u.loginAttemptRepo.RecordLockout(ctx, key, loginLockoutMemory)
+	if err != nil {
+		return 0, err
+	}
+
+	lockout := u.lockoutDuration(lockouts)
+	if err := u.loginAttemptRepo.Lock(ctx, key, lockout); err != nil {
+		return 0, err
+	}
+
+	return lockout, nil
+}
+
+// lockoutDuration returns LOGIN_LOCKOUT_BASE doubled for every earlier lockout, capped at LOGIN_LOCKOUT_MAX
+func (u *authUsecase) lockoutDuration(lockouts int64) time.Duration {
+	base := u.config.LoginLockoutBase
+	if base <= 0 {
+		base = defaultLoginLockoutBase
+	}
+	maxLockout := u.config.LoginLockoutMax
+	if maxLockout <= 0 {
+		maxLockout = defaultLoginLockoutMax
+	}
+
+	lockout := base
+	for i := int64(1); i < lockouts && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, maxLockout)
+}
+
+// sendLockoutAlert notifies the Telegram chat through the worker
+func (u *authUsecase) sendLockoutAlert(ctx context.Context, message string) {
+	task, err := worker.NewTelegramMessageTask(ctx, u.config.TelegramChatID, message)
+	if err != nil {
+		u.logger.Errorf(ctx, "Failed to create telegram task: %v", err)
+		return
+	}
+
+	info, err := u.asynqClient.Enqueue(task)
+	if err != nil {
+		u.logger.Errorf(ctx, "Failed to enqueue telegram task: %v", err)
+		return
+	}
+	u.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
+}
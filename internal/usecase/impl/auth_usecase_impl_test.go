@@ -0,0 +1,229 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/repository/memory"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testPassword = "Str0ng!Password"
+
+type authTestEnv struct {
+	usecase     usecase.AuthUsecase
+	sessionRepo repository.SessionRepository
+}
+
+func newAuthTestEnv(t *testing.T) *authTestEnv {
+	t.Helper()
+
+	cfg := config.Config{
+		JWTSecret:  "test-secret",
+		BcryptCost: bcrypt.MinCost,
+	}
+	jwtManager, err := auth.NewJWTManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := &authTestEnv{sessionRepo: memory.NewSessionRepository()}
+	env.usecase, err = NewAuthUsecase(
+		newFakeUserRepository(&model.User{ID: 1, Email: "jane@example.com", Password: string(hashed)}),
+		fakeRoleRepository{},
+		fakeOrganizationRepository{},
+		env.sessionRepo,
+		nil,
+		nil,
+		memory.NewThrottleRepository(),
+		memory.NewLoginAttemptRepository(),
+		newFakeMFARepository(),
+		memory.NewMFAChallengeRepository(),
+		&fakeAuditLogRepository{},
+		nil,
+		fakeTransactor{},
+		jwtManager,
+		nil,
+		cfg,
+		testLogger,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return env
+}
+
+// login signs user 1 in and returns the token pair
+func (env *authTestEnv) login(t *testing.T) *model.TokenResponse {
+	t.Helper()
+
+	resp, err := env.usecase.Login(context.Background(), model.LoginRequest{Email: "jane@example.com", Password: testPassword}, model.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.TokenResponse == nil || resp.RefreshToken == "" {
+		t.Fatalf("Login = %+v, want a token pair", resp)
+	}
+	return resp.TokenResponse
+}
+
+func (env *authTestEnv) sessions(t *testing.T) []model.Session {
+	t.Helper()
+
+	sessions, err := env.sessionRepo.ListSessions(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sessions
+}
+
+func TestRefreshRotatesTokenPair(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+	tokens := env.login(t)
+
+	rotated, err := env.usecase.Refresh(ctx, tokens.RefreshToken, model.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.AccessToken == "" || rotated.RefreshToken == "" || rotated.RefreshToken == tokens.RefreshToken {
+		t.Fatalf("Refresh = %+v, want a new token pair", rotated)
+	}
+
+	// The new refresh token is in the same session and can be rotated in turn
+	if _, err := env.usecase.Refresh(ctx, rotated.RefreshToken, model.ClientInfo{}); err != nil {
+		t.Fatalf("Refresh with the rotated token = %v", err)
+	}
+	if sessions := env.sessions(t); len(sessions) != 1 {
+		t.Errorf("got %d sessions, want 1", len(sessions))
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+	tokens := env.login(t)
+	other := env.login(t)
+
+	rotated, err := env.usecase.Refresh(ctx, tokens.RefreshToken, model.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.usecase.Refresh(ctx, tokens.RefreshToken, model.ClientInfo{}); !errors.Is(err, usecase.ErrRefreshTokenReused) {
+		t.Fatalf("Refresh with a rotated token = %v, want ErrRefreshTokenReused", err)
+	}
+
+	// The whole family is revoked, including the token issued to the legitimate client
+	if _, err := env.usecase.Refresh(ctx, rotated.RefreshToken, model.ClientInfo{}); !errors.Is(err, usecase.ErrInvalidRefreshToken) {
+		t.Errorf("Refresh with the newest token of the family = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	// Other sessions of the user are not affected
+	if _, err := env.usecase.Refresh(ctx, other.RefreshToken, model.ClientInfo{}); err != nil {
+		t.Errorf("Refresh in another session = %v", err)
+	}
+	if sessions := env.sessions(t); len(sessions) != 1 {
+		t.Errorf("got %d sessions, want only the other one", len(sessions))
+	}
+}
+
+func TestLogoutInvalidatesRefreshToken(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+	tokens := env.login(t)
+
+	if err := env.usecase.Logout(ctx, tokens.RefreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.usecase.Refresh(ctx, tokens.RefreshToken, model.ClientInfo{}); !errors.Is(err, usecase.ErrInvalidRefreshToken) {
+		t.Errorf("Refresh after logout = %v, want ErrInvalidRefreshToken", err)
+	}
+	if err := env.usecase.Logout(ctx, tokens.RefreshToken); !errors.Is(err, usecase.ErrInvalidRefreshToken) {
+		t.Errorf("Logout twice = %v, want ErrInvalidRefreshToken", err)
+	}
+	if sessions := env.sessions(t); len(sessions) != 0 {
+		t.Errorf("got %d sessions after logout, want none", len(sessions))
+	}
+}
+
+// failingPasswordResetRepository fails to store reset tokens
+type failingPasswordResetRepository struct {
+	repository.PasswordResetRepository
+}
+
+func (failingPasswordResetRepository) Create(ctx context.Context, token *model.PasswordResetToken) error {
+	return errors.New("database is down")
+}
+
+func TestForgotPassword(t *testing.T) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Config{JWTSecret: "test-secret", BcryptCost: bcrypt.MinCost, LoginMaxFailures: 2, LoginMaxFailuresPerIP: 3}
+	jwtManager, err := auth.NewJWTManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := NewAuthUsecase(
+		newFakeUserRepository(&model.User{ID: 1, Email: "jane@example.com", Password: string(hashed)}),
+		fakeRoleRepository{},
+		fakeOrganizationRepository{},
+		memory.NewSessionRepository(),
+		failingPasswordResetRepository{},
+		nil,
+		memory.NewThrottleRepository(),
+		memory.NewLoginAttemptRepository(),
+		newFakeMFARepository(),
+		memory.NewMFAChallengeRepository(),
+		&fakeAuditLogRepository{},
+		nil,
+		fakeTransactor{},
+		jwtManager,
+		nil,
+		cfg,
+		testLogger,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	requests := []struct {
+		email string
+		ip    string
+		want  error
+	}{
+		// Failures for a registered email look like requests for an unknown one
+		{"jane@example.com", "203.0.113.7", nil},
+		{"nobody@example.com", "203.0.113.7", nil},
+		{"jane@example.com", "198.51.100.1", nil},
+		{"jane@example.com", "198.51.100.2", usecase.ErrPasswordResetThrottled},
+		{"other@example.com", "203.0.113.7", nil},
+		{"another@example.com", "203.0.113.7", usecase.ErrPasswordResetThrottled},
+	}
+	for i, r := range requests {
+		err := u.ForgotPassword(ctx, model.ForgotPasswordRequest{Email: r.email}, model.ClientInfo{IPAddress: r.ip})
+		if !errors.Is(err, r.want) {
+			t.Errorf("request %d: ForgotPassword(%s) = %v, want %v", i, r.email, err, r.want)
+		}
+	}
+
+	// Reset requests do not lock the account out of logging in
+	if _, err := u.Login(ctx, model.LoginRequest{Email: "jane@example.com", Password: testPassword}, model.ClientInfo{IPAddress: "203.0.113.7"}); err != nil {
+		t.Errorf("Login after reset requests = %v", err)
+	}
+}
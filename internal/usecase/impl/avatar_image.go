@@ -0,0 +1,139 @@
+package impl
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	// Register the GIF decoder for image.Decode
+	_ "image/gif"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+)
+
+const (
+	// avatarMaxPixels bounds the decoded size of an avatar, a small file can
+	// declare huge dimensions and exhaust memory when decoded
+	avatarMaxPixels = 25_000_000
+	// avatarMinEdge is the smallest width or height accepted
+	avatarMinEdge        = 16
+	thumbnailJPEGQuality = 85
+)
+
+// avatarExtensions are the file extensions of the accepted avatar types
+var avatarExtensions = map[string]string{
+	model.AvatarContentTypeJPEG: ".jpg",
+	model.AvatarContentTypePNG:  ".png",
+	model.AvatarContentTypeGIF:  ".gif",
+}
+
+// checkAvatarImage sniffs the content type from the data instead of trusting the
+// client and checks the declared dimensions before anything is decoded
+func checkAvatarImage(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := avatarExtensions[contentType]; !ok {
+		return "", apperror.Detail(usecase.ErrUnsupportedAvatarType, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", usecase.ErrInvalidAvatar, err)
+	}
+	if config.Width < avatarMinEdge || config.Height < avatarMinEdge {
+		return "", apperror.Detail(usecase.ErrInvalidAvatar, fmt.Sprintf("must be at least %dx%d pixels", avatarMinEdge, avatarMinEdge))
+	}
+	if config.Width*config.Height > avatarMaxPixels {
+		return "", apperror.Detail(usecase.ErrInvalidAvatar, fmt.Sprintf("must be at most %d pixels", avatarMaxPixels))
+	}
+
+	return contentType, nil
+}
+
+// avatarThumbnailKey returns the storage key of a thumbnail next to the avatar,
+// avatars/7/3f9a.png becomes avatars/7/3f9a_64.png
+func avatarThumbnailKey(avatarKey string, size int) string {
+	ext := path.Ext(avatarKey)
+	return strings.TrimSuffix(avatarKey, ext) + "_" + strconv.Itoa(size) + thumbnailExtension(ext)
+}
+
+// thumbnailExtension keeps JPEG avatars as JPEG, the other types become PNG to keep transparency
+func thumbnailExtension(avatarExt string) string {
+	if avatarExt == ".jpg" {
+		return ".jpg"
+	}
+	return ".png"
+}
+
+// encodeThumbnail encodes the thumbnail in the format matching its key
+func encodeThumbnail(img image.Image, key string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if path.Ext(key) == ".jpg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), model.AvatarContentTypeJPEG, nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), model.AvatarContentTypePNG, nil
+}
+
+// cropSquare returns the largest centered square of the image
+func cropSquare(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	edge := min(b.Dx(), b.Dy())
+	x := b.Min.X + (b.Dx()-edge)/2
+	y := b.Min.Y + (b.Dy()-edge)/2
+	return image.Rect(x, y, x+edge, y+edge)
+}
+
+// resizeSquare scales the square area of the image to size x size pixels.
+// Each target pixel averages the source pixels it covers, which is what a
+// downscale needs to stay free of aliasing. Sources smaller than the target
+// are scaled up by repeating pixels.
+func resizeSquare(img image.Image, area image.Rectangle, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	edge := area.Dx()
+
+	for y := 0; y < size; y++ {
+		y0 := area.Min.Y + y*edge/size
+		y1 := max(area.Min.Y+(y+1)*edge/size, y0+1)
+		for x := 0; x < size; x++ {
+			x0 := area.Min.X + x*edge/size
+			x1 := max(area.Min.X+(x+1)*edge/size, x0+1)
+
+			// RGBA returns alpha-premultiplied values, so averaging them blends transparency correctly
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
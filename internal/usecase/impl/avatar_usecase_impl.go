@@ -0,0 +1,221 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"slices"
+	"strconv"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/integration/storage"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/internal/worker"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+)
+
+const defaultAvatarMaxBytes = 5 << 20
+
+type avatarUsecase struct {
+	userRepo    repository.UserRepository
+	txManager   database.Transactor
+	storage     storage.Storage
+	asynqClient *asynq.Client
+	audit       *auditRecorder
+	cache       *userCache
+	config      config.Config
+	logger      *logger.Logger
+}
+
+func NewAvatarUsecase(
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	userCacheRepo repository.UserCacheRepository,
+	txManager database.Transactor,
+	fileStorage storage.Storage,
+	asynqClient *asynq.Client,
+	cfg config.Config,
+	log *logger.Logger,
+) usecase.AvatarUsecase {
+	return &avatarUsecase{
+		userRepo:    userRepo,
+		txManager:   txManager,
+		storage:     fileStorage,
+		asynqClient: asynqClient,
+		audit:       newAuditRecorder(auditLogRepo),
+		cache:       newUserCache(userCacheRepo, log),
+		config:      cfg,
+		logger:      log,
+	}
+}
+
+// UploadAvatar stores the image under a new key so cached URLs of the previous
+// avatar never serve the new one. The previous avatar is deleted once the user
+// points to the new one.
+func (u *avatarUsecase) UploadAvatar(ctx context.Context, userID int64, data []byte) (*model.UserResponse, error) {
+	if int64(len(data)) > u.MaxBytes() {
+		return nil, usecase.ErrAvatarTooLarge
+	}
+
+	contentType, err := checkAvatarImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, usecase.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	key, err := newAvatarKey(userID, avatarExtensions[contentType])
+	if err != nil {
+		return nil, err
+	}
+	if err := u.storage.Put(ctx, key, data, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+
+	before := userAuditFields(user)
+	previousKey := user.AvatarKey
+
+	url := u.storage.URL(key)
+	user.AvatarKey = &key
+	user.AvatarURL = &url
+	user.AvatarThumbnails = nil
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.userRepo.Update(txCtx, user, []string{"avatar_key", "avatar_url", "avatar_thumbnails"}); err != nil {
+			return err
+		}
+		return u.audit.recordUser(txCtx, model.AuditActionUserAvatarUpdated, user.ID, before, userAuditFields(user))
+	})
+	if err != nil {
+		u.deleteAvatar(ctx, key)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, usecase.ErrPreconditionFailed
+		}
+		return nil, err
+	}
+
+	u.cache.invalidate(ctx, user.ID)
+
+	if previousKey != nil {
+		u.deleteAvatar(ctx, *previousKey)
+	}
+
+	// The avatar is served without thumbnails if the task cannot be enqueued
+	task, err := worker.NewAvatarThumbnailsTask(ctx, user.ID, key)
+	if err != nil {
+		u.logger.Errorf(ctx, "Failed to create avatar thumbnails task: %v", err)
+	} else if info, err := u.asynqClient.Enqueue(task); err != nil {
+		u.logger.Errorf(ctx, "Failed to enqueue avatar thumbnails task: %v", err)
+	} else {
+		u.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
+	}
+
+	response := user.ToResponse()
+	return &response, nil
+}
+
+// GenerateThumbnails resizes the avatar into every thumbnail size, largest first so
+// each smaller one is scaled from the previous one instead of the full image
+func (u *avatarUsecase) GenerateThumbnails(ctx context.Context, userID int64, avatarKey string) error {
+	data, err := u.storage.Get(ctx, avatarKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			u.logger.Infof(ctx, "Avatar %s was replaced, skipping thumbnails", avatarKey)
+			return nil
+		}
+		return err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		// Retrying cannot fix the image, the avatar stays usable without thumbnails
+		u.logger.Warnf(ctx, "Failed to decode avatar %s: %v", avatarKey, err)
+		return nil
+	}
+
+	sizes := slices.Clone(model.AvatarThumbnailSizes)
+	slices.Sort(sizes)
+	slices.Reverse(sizes)
+
+	thumbnails := make(model.AvatarThumbnails, len(sizes))
+	var keys []string
+	src, area := img, cropSquare(img)
+	for _, size := range sizes {
+		thumbnail := resizeSquare(src, area, size)
+		src, area = thumbnail, thumbnail.Bounds()
+
+		key := avatarThumbnailKey(avatarKey, size)
+		encoded, contentType, err := encodeThumbnail(thumbnail, key)
+		if err != nil {
+			return fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		if err := u.storage.Put(ctx, key, encoded, contentType); err != nil {
+			return fmt.Errorf("failed to store thumbnail: %w", err)
+		}
+
+		keys = append(keys, key)
+		thumbnails[strconv.Itoa(size)] = u.storage.URL(key)
+	}
+
+	updated, err := u.userRepo.UpdateAvatarThumbnails(ctx, userID, avatarKey, thumbnails)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		// The avatar was replaced while resizing, nothing points to these thumbnails
+		for _, key := range keys {
+			if err := u.storage.Delete(ctx, key); err != nil {
+				u.logger.Errorf(ctx, "Failed to delete thumbnail %s: %v", key, err)
+			}
+		}
+		return nil
+	}
+
+	u.cache.invalidate(ctx, userID)
+	return nil
+}
+
+// deleteAvatar removes an avatar and its thumbnails. Failures only leave unused files behind.
+func (u *avatarUsecase) deleteAvatar(ctx context.Context, avatarKey string) {
+	keys := []string{avatarKey}
+	for _, size := range model.AvatarThumbnailSizes {
+		keys = append(keys, avatarThumbnailKey(avatarKey, size))
+	}
+
+	for _, key := range keys {
+		if err := u.storage.Delete(ctx, key); err != nil {
+			u.logger.Errorf(ctx, "Failed to delete avatar file %s: %v", key, err)
+		}
+	}
+}
+
+func (u *avatarUsecase) MaxBytes() int64 {
+	if u.config.AvatarMaxBytes <= 0 {
+		return defaultAvatarMaxBytes
+	}
+	return u.config.AvatarMaxBytes
+}
+
+// newAvatarKey returns a fresh random storage key for an avatar of the user
+func newAvatarKey(userID int64, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate avatar key: %w", err)
+	}
+	return fmt.Sprintf("avatars/%d/%s%s", userID, hex.EncodeToString(b), ext), nil
+}
@@ -0,0 +1,82 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/worker"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+)
+
+const defaultEmailVerificationTokenTTL = 24 * time.Hour
+
+// emailVerificationSender issues verification tokens and enqueues the email.
+// It is shared by the user usecase (sign up, email change) and the auth usecase (resend).
+type emailVerificationSender struct {
+	emailVerificationRepo repository.EmailVerificationRepository
+	asynqClient           *asynq.Client
+	config                config.Config
+	logger                *logger.Logger
+}
+
+func newEmailVerificationSender(
+	emailVerificationRepo repository.EmailVerificationRepository,
+	asynqClient *asynq.Client,
+	cfg config.Config,
+	log *logger.Logger,
+) *emailVerificationSender {
+	return &emailVerificationSender{
+		emailVerificationRepo: emailVerificationRepo,
+		asynqClient:           asynqClient,
+		config:                cfg,
+		logger:                log,
+	}
+}
+
+// send invalidates earlier verification links of the user and emails a new one
+func (s *emailVerificationSender) send(ctx context.Context, user *model.User) error {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	ttl := s.config.EmailVerificationTokenTTL
+	if ttl <= 0 {
+		ttl = defaultEmailVerificationTokenTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	if err := s.emailVerificationRepo.InvalidateForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	err = s.emailVerificationRepo.Create(ctx, &model.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	verifyURL := fmt.Sprintf("%s?token=%s", s.config.EmailVerificationURL, token)
+	task, err := worker.NewEmailVerificationTask(ctx, user.Email, user.Name, verifyURL, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification task: %w", err)
+	}
+
+	info, err := s.asynqClient.Enqueue(task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue email verification task: %w", err)
+	}
+	s.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+// The fakes below keep just enough state for the usecases under test.
+// Methods a test does not reach fall through to the nil embedded interface and panic.
+
+var testLogger = logger.NewLogger()
+
+// fakeTransactor runs the function without a transaction
+type fakeTransactor struct {
+	database.Transactor
+}
+
+func (fakeTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+
+	mu    sync.Mutex
+	users map[int64]*model.User
+}
+
+func newFakeUserRepository(users ...*model.User) *fakeUserRepository {
+	r := &fakeUserRepository{users: make(map[int64]*model.User)}
+	for _, user := range users {
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) Delete(ctx context.Context, id int64, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return repository.ErrUserNotFound
+	}
+	if user.Version != version {
+		return repository.ErrVersionConflict
+	}
+	delete(r.users, id)
+	return nil
+}
+
+// fakeUserCacheRepository records the users whose cached response was dropped
+type fakeUserCacheRepository struct {
+	mu          sync.Mutex
+	invalidated []int64
+}
+
+func (r *fakeUserCacheRepository) Invalidate(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.invalidated = append(r.invalidated, userID)
+	return nil
+}
+
+// fakeRoleRepository grants no roles or permissions
+type fakeRoleRepository struct {
+	repository.RoleRepository
+}
+
+func (fakeRoleRepository) GetUserRoles(ctx context.Context, userID int64) ([]model.Role, error) {
+	return nil, nil
+}
+
+func (fakeRoleRepository) GetUserPermissions(ctx context.Context, userID int64) ([]string, error) {
+	return nil, nil
+}
+
+// fakeOrganizationRepository has users in no organization
+type fakeOrganizationRepository struct {
+	repository.OrganizationRepository
+}
+
+func (fakeOrganizationRepository) GetByUserID(ctx context.Context, userID int64) ([]model.Organization, error) {
+	return nil, nil
+}
+
+type fakeMFARepository struct {
+	mu            sync.Mutex
+	mfas          map[int64]model.UserMFA
+	recoveryCodes map[int64]map[string]bool
+}
+
+func newFakeMFARepository() *fakeMFARepository {
+	return &fakeMFARepository{
+		mfas:          make(map[int64]model.UserMFA),
+		recoveryCodes: make(map[int64]map[string]bool),
+	}
+}
+
+func (r *fakeMFARepository) GetByUserID(ctx context.Context, userID int64) (*model.UserMFA, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.mfas[userID]
+	if !ok {
+		return nil, repository.ErrMFANotFound
+	}
+	return &mfa, nil
+}
+
+func (r *fakeMFARepository) Upsert(ctx context.Context, mfa *model.UserMFA) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.mfas[mfa.UserID]; ok && existing.IsEnabled() {
+		return false, nil
+	}
+	r.mfas[mfa.UserID] = *mfa
+	return true, nil
+}
+
+func (r *fakeMFARepository) Confirm(ctx context.Context, userID int64, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.mfas[userID]
+	if !ok || mfa.IsEnabled() {
+		return repository.ErrMFANotFound
+	}
+	now := time.Now()
+	mfa.ConfirmedAt = &now
+	mfa.LastUsedStep = step
+	r.mfas[userID] = mfa
+	return nil
+}
+
+func (r *fakeMFARepository) UseStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.mfas[userID]
+	if !ok || step <= mfa.LastUsedStep {
+		return false, nil
+	}
+	mfa.LastUsedStep = step
+	r.mfas[userID] = mfa
+	return true, nil
+}
+
+func (r *fakeMFARepository) Delete(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.mfas, userID)
+	delete(r.recoveryCodes, userID)
+	return nil
+}
+
+func (r *fakeMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	codes := make(map[string]bool, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes[hash] = false
+	}
+	r.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (r *fakeMFARepository) ConsumeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	used, ok := r.recoveryCodes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	r.recoveryCodes[userID][codeHash] = true
+	return true, nil
+}
+
+type fakeAuditLogRepository struct {
+	repository.AuditLogRepository
+
+	mu   sync.Mutex
+	logs []*model.AuditLog
+}
+
+func (r *fakeAuditLogRepository) Create(ctx context.Context, log *model.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeAuditLogRepository) CreateBatch(ctx context.Context, logs []*model.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logs = append(r.logs, logs...)
+	return nil
+}
+
+// actions returns the recorded audit actions in order
+func (r *fakeAuditLogRepository) actions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	actions := make([]string, len(r.logs))
+	for i, log := range r.logs {
+		actions[i] = log.Action
+	}
+	return actions
+}
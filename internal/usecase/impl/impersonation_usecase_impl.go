@@ -0,0 +1,122 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+const defaultImpersonationTokenTTL = 15 * time.Minute
+
+type impersonationUsecase struct {
+	userRepo    repository.UserRepository
+	roleRepo    repository.RoleRepository
+	sessionRepo repository.SessionRepository
+	tokenIssuer *tokenIssuer
+	audit       *auditRecorder
+	config      config.Config
+	logger      *logger.Logger
+}
+
+func NewImpersonationUsecase(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	organizationRepo repository.OrganizationRepository,
+	sessionRepo repository.SessionRepository,
+	mfaRepo repository.MFARepository,
+	mfaChallengeRepo repository.MFAChallengeRepository,
+	auditLogRepo repository.AuditLogRepository,
+	jwtManager *auth.JWTManager,
+	cfg config.Config,
+	log *logger.Logger,
+) usecase.ImpersonationUsecase {
+	return &impersonationUsecase{
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		sessionRepo: sessionRepo,
+		tokenIssuer: newTokenIssuer(roleRepo, organizationRepo, sessionRepo, mfaRepo, mfaChallengeRepo, jwtManager, cfg),
+		audit:       newAuditRecorder(auditLogRepo),
+		config:      cfg,
+		logger:      log,
+	}
+}
+
+// StartImpersonation issues the token only if the caller holds every permission of the user,
+// so impersonation never grants an admin more than they already have.
+// The token is only handed out once the audit log of it is written.
+func (u *impersonationUsecase) StartImpersonation(ctx context.Context, userID int64, client model.ClientInfo) (*model.ImpersonationResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims.UserID == 0 || claims.IsImpersonation() || claims.UserID == userID {
+		return nil, usecase.ErrImpersonationForbidden
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, usecase.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	permissions, err := u.roleRepo.GetUserPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range permissions {
+		if !claims.HasPermission(permission) {
+			return nil, usecase.ErrImpersonationForbidden
+		}
+	}
+
+	token, sessionID, err := u.tokenIssuer.startImpersonation(ctx, user, claims.UserID, client, u.tokenTTL())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.audit.record(ctx, model.AuditActionUserImpersonationStarted, model.AuditEntityUser, user.ID, nil); err != nil {
+		if revokeErr := u.sessionRepo.RevokeFamily(ctx, sessionID); revokeErr != nil {
+			u.logger.Errorf(ctx, "Failed to revoke unaudited impersonation session: %v", revokeErr)
+		}
+		return nil, err
+	}
+
+	u.logger.Infof(ctx, "User %d started impersonating user %d", claims.UserID, user.ID)
+
+	return &model.ImpersonationResponse{
+		TokenResponse:  *token,
+		ImpersonatorID: claims.UserID,
+		User:           user.ToResponse(),
+	}, nil
+}
+
+func (u *impersonationUsecase) EndImpersonation(ctx context.Context) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || !claims.IsImpersonation() {
+		return usecase.ErrNotImpersonating
+	}
+
+	if err := u.sessionRepo.RevokeFamily(ctx, claims.SessionID); err != nil {
+		return err
+	}
+
+	if err := u.audit.record(ctx, model.AuditActionUserImpersonationEnded, model.AuditEntityUser, claims.UserID, nil); err != nil {
+		return err
+	}
+
+	u.logger.Infof(ctx, "User %d stopped impersonating user %d", claims.ImpersonatorID, claims.UserID)
+	return nil
+}
+
+func (u *impersonationUsecase) tokenTTL() time.Duration {
+	if u.config.ImpersonationTokenTTL <= 0 {
+		return defaultImpersonationTokenTTL
+	}
+	return u.config.ImpersonationTokenTTL
+}
@@ -0,0 +1,61 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+)
+
+// mfaVerifier checks second factor codes of users with confirmed 2FA.
+// It is shared by the auth usecase (login) and the MFA usecase.
+type mfaVerifier struct {
+	mfaRepo repository.MFARepository
+	// now is the clock codes are checked against
+	now func() time.Time
+}
+
+func newMFAVerifier(mfaRepo repository.MFARepository) *mfaVerifier {
+	return &mfaVerifier{
+		mfaRepo: mfaRepo,
+		now:     time.Now,
+	}
+}
+
+// verify accepts a current TOTP code or an unused recovery code.
+// Accepted codes are burned so neither can be replayed.
+func (v *mfaVerifier) verify(ctx context.Context, userID int64, code string) error {
+	mfa, err := v.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !mfa.IsEnabled() {
+		return usecase.ErrInvalidMFACode
+	}
+
+	if step, ok := auth.ValidateTOTP(mfa.Secret, code, v.now()); ok {
+		used, err := v.mfaRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return usecase.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	consumed, err := v.mfaRepo.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return usecase.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func hashRecoveryCode(code string) string {
+	return auth.HashToken(auth.NormalizeRecoveryCode(code))
+}
@@ -0,0 +1,169 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+const (
+	mfaRecoveryCodeCount = 10
+	defaultMFAIssuer     = "go-gin-sqlx-template"
+)
+
+type mfaUsecase struct {
+	userRepo  repository.UserRepository
+	mfaRepo   repository.MFARepository
+	txManager database.Transactor
+	audit     *auditRecorder
+	config    config.Config
+	logger    *logger.Logger
+	// now is the clock confirmation codes are checked against
+	now func() time.Time
+}
+
+func NewMFAUsecase(
+	userRepo repository.UserRepository,
+	mfaRepo repository.MFARepository,
+	auditLogRepo repository.AuditLogRepository,
+	txManager database.Transactor,
+	cfg config.Config,
+	log *logger.Logger,
+) usecase.MFAUsecase {
+	return &mfaUsecase{
+		userRepo:  userRepo,
+		mfaRepo:   mfaRepo,
+		txManager: txManager,
+		audit:     newAuditRecorder(auditLogRepo),
+		config:    cfg,
+		logger:    log,
+		now:       time.Now,
+	}
+}
+
+// Enroll generates a new TOTP secret for the user. It stays inactive until confirmed,
+// enrolling again before confirming replaces the pending secret.
+func (u *mfaUsecase) Enroll(ctx context.Context, userID int64) (*model.EnrollMFAResponse, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		stored, err := u.mfaRepo.Upsert(txCtx, &model.UserMFA{
+			UserID: user.ID,
+			Secret: secret,
+		})
+		if err != nil {
+			return err
+		}
+		if !stored {
+			return usecase.ErrMFAAlreadyEnabled
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserMFAEnrolled, model.AuditEntityUser, user.ID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.EnrollMFAResponse{
+		Secret:     secret,
+		OTPAuthURI: auth.TOTPURI(u.issuer(), user.Email, secret),
+	}, nil
+}
+
+// Confirm enables 2FA once the user proves the authenticator app works,
+// and returns a fresh set of recovery codes
+func (u *mfaUsecase) Confirm(ctx context.Context, userID int64, req model.ConfirmMFARequest) (*model.ConfirmMFAResponse, error) {
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFANotFound) {
+			return nil, usecase.ErrMFANotEnrolled
+		}
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, usecase.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := auth.ValidateTOTP(mfa.Secret, req.Code, u.now())
+	if !ok {
+		return nil, usecase.ErrInvalidMFACode
+	}
+
+	codes, err := auth.GenerateRecoveryCodes(mfaRecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	codeHashes := make([]string, len(codes))
+	for i, code := range codes {
+		codeHashes[i] = hashRecoveryCode(code)
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.mfaRepo.Confirm(txCtx, userID, step); err != nil {
+			if errors.Is(err, repository.ErrMFANotFound) {
+				return usecase.ErrMFAAlreadyEnabled
+			}
+			return err
+		}
+
+		if err := u.mfaRepo.ReplaceRecoveryCodes(txCtx, userID, codeHashes); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserMFAEnabled, model.AuditEntityUser, userID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ConfirmMFAResponse{RecoveryCodes: codes}, nil
+}
+
+// Reset removes the user's 2FA so they can log in with the password alone and enroll again
+func (u *mfaUsecase) Reset(ctx context.Context, userID int64) error {
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.mfaRepo.Delete(txCtx, userID); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserMFAReset, model.AuditEntityUser, userID, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.logger.Infof(ctx, "two-factor authentication reset for user %d", userID)
+	return nil
+}
+
+// issuer returns MFA_ISSUER, falling back to the JWT issuer
+func (u *mfaUsecase) issuer() string {
+	if u.config.MFAIssuer != "" {
+		return u.config.MFAIssuer
+	}
+	if u.config.JWTIssuer != "" {
+		return u.config.JWTIssuer
+	}
+	return defaultMFAIssuer
+}
@@ -0,0 +1,198 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+)
+
+// testClock is a fixed point in time, moved forward by the tests to the next TOTP period
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+type mfaTestEnv struct {
+	usecase  *mfaUsecase
+	verifier *mfaVerifier
+	mfaRepo  *fakeMFARepository
+	auditLog *fakeAuditLogRepository
+	clock    *testClock
+}
+
+func newMFATestEnv() *mfaTestEnv {
+	env := &mfaTestEnv{
+		mfaRepo:  newFakeMFARepository(),
+		auditLog: &fakeAuditLogRepository{},
+		clock:    &testClock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	userRepo := newFakeUserRepository(&model.User{ID: 1, Email: "jane@example.com"})
+
+	env.usecase = NewMFAUsecase(userRepo, env.mfaRepo, env.auditLog, fakeTransactor{}, config.Config{MFAIssuer: "Example"}, testLogger).(*mfaUsecase)
+	env.usecase.now = env.clock.Now
+	env.verifier = newMFAVerifier(env.mfaRepo)
+	env.verifier.now = env.clock.Now
+	return env
+}
+
+// code returns the TOTP code of the current period
+func (env *mfaTestEnv) code(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := auth.GenerateTOTPCode(secret, env.clock.now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+// enable enrolls and confirms 2FA for user 1 and returns the secret and recovery codes
+func (env *mfaTestEnv) enable(t *testing.T) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	enrollment, err := env.usecase.Enroll(ctx, 1)
+	if err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	confirmed, err := env.usecase.Confirm(ctx, 1, model.ConfirmMFARequest{Code: env.code(t, enrollment.Secret)})
+	if err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	return enrollment.Secret, confirmed.RecoveryCodes
+}
+
+func TestMFAEnrollAndConfirm(t *testing.T) {
+	env := newMFATestEnv()
+	ctx := context.Background()
+
+	enrollment, err := env.usecase.Enroll(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(enrollment.OTPAuthURI, "otpauth://totp/Example:jane@example.com?") ||
+		!strings.Contains(enrollment.OTPAuthURI, "secret="+enrollment.Secret) {
+		t.Errorf("OTPAuthURI = %s", enrollment.OTPAuthURI)
+	}
+
+	// Enrolling again before confirming replaces the pending secret
+	enrollment, err = env.usecase.Enroll(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.usecase.Confirm(ctx, 1, model.ConfirmMFARequest{Code: "000000"}); !errors.Is(err, usecase.ErrInvalidMFACode) {
+		t.Fatalf("Confirm with a wrong code = %v, want ErrInvalidMFACode", err)
+	}
+
+	confirmed, err := env.usecase.Confirm(ctx, 1, model.ConfirmMFARequest{Code: env.code(t, enrollment.Secret)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(confirmed.RecoveryCodes) != mfaRecoveryCodeCount {
+		t.Errorf("got %d recovery codes, want %d", len(confirmed.RecoveryCodes), mfaRecoveryCodeCount)
+	}
+	mfa, err := env.mfaRepo.GetByUserID(ctx, 1)
+	if err != nil || !mfa.IsEnabled() || mfa.Secret != enrollment.Secret {
+		t.Fatalf("stored mfa = %+v, %v, want the confirmed secret", mfa, err)
+	}
+
+	if _, err := env.usecase.Enroll(ctx, 1); !errors.Is(err, usecase.ErrMFAAlreadyEnabled) {
+		t.Errorf("Enroll once enabled = %v, want ErrMFAAlreadyEnabled", err)
+	}
+	if _, err := env.usecase.Confirm(ctx, 1, model.ConfirmMFARequest{Code: env.code(t, enrollment.Secret)}); !errors.Is(err, usecase.ErrMFAAlreadyEnabled) {
+		t.Errorf("Confirm once enabled = %v, want ErrMFAAlreadyEnabled", err)
+	}
+
+	want := []string{model.AuditActionUserMFAEnrolled, model.AuditActionUserMFAEnrolled, model.AuditActionUserMFAEnabled}
+	if got := env.auditLog.actions(); !slices.Equal(got, want) {
+		t.Errorf("audit actions = %v, want %v", got, want)
+	}
+}
+
+func TestMFAConfirmWithoutEnrollment(t *testing.T) {
+	env := newMFATestEnv()
+
+	if _, err := env.usecase.Confirm(context.Background(), 1, model.ConfirmMFARequest{Code: "123456"}); !errors.Is(err, usecase.ErrMFANotEnrolled) {
+		t.Errorf("Confirm = %v, want ErrMFANotEnrolled", err)
+	}
+}
+
+func TestMFAVerifyRejectsReplayedCode(t *testing.T) {
+	env := newMFATestEnv()
+	ctx := context.Background()
+	secret, _ := env.enable(t)
+
+	// The code used to confirm cannot be used to log in
+	if err := env.verifier.verify(ctx, 1, env.code(t, secret)); !errors.Is(err, usecase.ErrInvalidMFACode) {
+		t.Fatalf("verify with the confirmation code = %v, want ErrInvalidMFACode", err)
+	}
+
+	previous := env.code(t, secret)
+	env.clock.now = env.clock.now.Add(30 * time.Second)
+	code := env.code(t, secret)
+	if err := env.verifier.verify(ctx, 1, code); err != nil {
+		t.Fatalf("verify with the code of the next period = %v", err)
+	}
+	if err := env.verifier.verify(ctx, 1, code); !errors.Is(err, usecase.ErrInvalidMFACode) {
+		t.Errorf("verify with a replayed code = %v, want ErrInvalidMFACode", err)
+	}
+
+	// Codes of earlier periods are still within the skew but were superseded
+	if err := env.verifier.verify(ctx, 1, previous); !errors.Is(err, usecase.ErrInvalidMFACode) {
+		t.Errorf("verify with an earlier code = %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestMFAVerifyRecoveryCodesAreSingleUse(t *testing.T) {
+	env := newMFATestEnv()
+	ctx := context.Background()
+	_, recoveryCodes := env.enable(t)
+
+	if err := env.verifier.verify(ctx, 1, recoveryCodes[0]); err != nil {
+		t.Fatalf("verify with a recovery code = %v", err)
+	}
+	if err := env.verifier.verify(ctx, 1, recoveryCodes[0]); !errors.Is(err, usecase.ErrInvalidMFACode) {
+		t.Errorf("verify with a used recovery code = %v, want ErrInvalidMFACode", err)
+	}
+
+	// Recovery codes are accepted as typed from the printout
+	if err := env.verifier.verify(ctx, 1, " "+strings.ToUpper(recoveryCodes[1])+" "); err != nil {
+		t.Errorf("verify with an uppercase recovery code = %v", err)
+	}
+
+	if err := env.verifier.verify(ctx, 1, "aaaa-bbbb"); !errors.Is(err, usecase.ErrInvalidMFACode) {
+		t.Errorf("verify with an unknown recovery code = %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestMFAReset(t *testing.T) {
+	env := newMFATestEnv()
+	ctx := context.Background()
+	_, recoveryCodes := env.enable(t)
+
+	if err := env.usecase.Reset(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.verifier.verify(ctx, 1, recoveryCodes[0]); err == nil {
+		t.Error("verify with a recovery code after the reset succeeded")
+	}
+	if _, err := env.usecase.Enroll(ctx, 1); err != nil {
+		t.Errorf("Enroll after the reset = %v", err)
+	}
+
+	if got := env.auditLog.actions(); !slices.Contains(got, model.AuditActionUserMFAReset) {
+		t.Errorf("audit actions = %v, want %s", got, model.AuditActionUserMFAReset)
+	}
+}
@@ -0,0 +1,231 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/integration/oidc"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+const defaultOIDCStateTTL = 10 * time.Minute
+
+type oidcUsecase struct {
+	userRepo      repository.UserRepository
+	identityRepo  repository.IdentityRepository
+	oidcStateRepo repository.OIDCStateRepository
+	txManager     database.Transactor
+	tokenIssuer   *tokenIssuer
+	audit         *auditRecorder
+	cache         *userCache
+	// providers in configuration order, and by name for lookups
+	providers     []*oidc.Provider
+	providersByID map[string]*oidc.Provider
+	config        config.Config
+	logger        *logger.Logger
+}
+
+func NewOIDCUsecase(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	organizationRepo repository.OrganizationRepository,
+	sessionRepo repository.SessionRepository,
+	mfaRepo repository.MFARepository,
+	mfaChallengeRepo repository.MFAChallengeRepository,
+	identityRepo repository.IdentityRepository,
+	oidcStateRepo repository.OIDCStateRepository,
+	auditLogRepo repository.AuditLogRepository,
+	userCacheRepo repository.UserCacheRepository,
+	txManager database.Transactor,
+	jwtManager *auth.JWTManager,
+	cfg config.Config,
+	log *logger.Logger,
+) usecase.OIDCUsecase {
+	providers := make([]*oidc.Provider, 0, len(cfg.OIDCProviders))
+	providersByID := make(map[string]*oidc.Provider, len(cfg.OIDCProviders))
+	for _, providerConfig := range cfg.OIDCProviders {
+		provider := oidc.NewProvider(providerConfig)
+		providers = append(providers, provider)
+		providersByID[provider.Name()] = provider
+	}
+
+	return &oidcUsecase{
+		userRepo:      userRepo,
+		identityRepo:  identityRepo,
+		oidcStateRepo: oidcStateRepo,
+		txManager:     txManager,
+		tokenIssuer:   newTokenIssuer(roleRepo, organizationRepo, sessionRepo, mfaRepo, mfaChallengeRepo, jwtManager, cfg),
+		audit:         newAuditRecorder(auditLogRepo),
+		cache:         newUserCache(userCacheRepo, log),
+		providers:     providers,
+		providersByID: providersByID,
+		config:        cfg,
+		logger:        log,
+	}
+}
+
+func (u *oidcUsecase) ListProviders(ctx context.Context) []model.OIDCProviderResponse {
+	responses := make([]model.OIDCProviderResponse, len(u.providers))
+	for i, provider := range u.providers {
+		responses[i] = model.OIDCProviderResponse{
+			Name:     provider.Name(),
+			LoginURL: fmt.Sprintf("/api/v1/auth/oidc/%s/login", provider.Name()),
+		}
+	}
+	return responses
+}
+
+// AuthorizationURL stores a fresh state, nonce and PKCE verifier for the login
+// and returns the provider's authorization URL
+func (u *oidcUsecase) AuthorizationURL(ctx context.Context, providerName string) (string, error) {
+	provider, ok := u.providersByID[providerName]
+	if !ok {
+		return "", usecase.ErrUnknownProvider
+	}
+
+	state, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	ttl := u.config.OIDCStateTTL
+	if ttl <= 0 {
+		ttl = defaultOIDCStateTTL
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", usecase.ErrProviderUnavailable, err)
+	}
+
+	err = u.oidcStateRepo.Save(ctx, &model.OIDCState{
+		StateHash:    auth.HashToken(state),
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+// Callback verifies the provider's ID token and logs in the linked user.
+// A provider account seen for the first time is linked to the user with the
+// same email, but only if the provider reports that email as verified.
+func (u *oidcUsecase) Callback(ctx context.Context, providerName, code, state string, client model.ClientInfo) (*model.LoginResponse, error) {
+	provider, ok := u.providersByID[providerName]
+	if !ok {
+		return nil, usecase.ErrUnknownProvider
+	}
+
+	stored, err := u.oidcStateRepo.Consume(ctx, auth.HashToken(state))
+	if err != nil {
+		if errors.Is(err, repository.ErrOIDCStateNotFound) {
+			return nil, usecase.ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+	if stored.Provider != providerName {
+		return nil, usecase.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, code, stored.CodeVerifier, stored.Nonce)
+	if err != nil {
+		u.logger.Warnf(ctx, "oidc login with %s failed: %v", providerName, err)
+		return nil, fmt.Errorf("%w: %v", usecase.ErrOIDCLoginFailed, err)
+	}
+
+	user, err := u.resolveUser(ctx, providerName, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.tokenIssuer.completeLogin(ctx, user, client)
+}
+
+// resolveUser returns the user linked to the provider account, linking it by verified email on first login
+func (u *oidcUsecase) resolveUser(ctx context.Context, providerName string, claims *oidc.IDTokenClaims) (*model.User, error) {
+	identity, err := u.identityRepo.GetByProviderSubject(ctx, providerName, claims.Subject)
+	if err == nil {
+		user, err := u.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return nil, usecase.ErrOIDCAccountNotFound
+			}
+			return nil, err
+		}
+
+		if err := u.identityRepo.TouchLastLogin(ctx, identity.ID); err != nil {
+			u.logger.Errorf(ctx, "Failed to update identity last login: %v", err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, repository.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// Linking by an unverified email would let anyone who can register that
+	// address at the provider take over the local account
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, usecase.ErrOIDCEmailNotVerified
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, claims.Email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, usecase.ErrOIDCAccountNotFound
+		}
+		return nil, err
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		err := u.identityRepo.Create(txCtx, &model.UserIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The provider has proven ownership of the address
+		if user.EmailVerifiedAt == nil {
+			if err := u.userRepo.MarkEmailVerified(txCtx, user.ID); err != nil {
+				return err
+			}
+			return u.audit.record(txCtx, model.AuditActionUserEmailVerified, model.AuditEntityUser, user.ID, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Marking the email verified bumped the version
+	if user.EmailVerifiedAt == nil {
+		u.cache.invalidate(ctx, user.ID)
+	}
+
+	u.logger.Infof(ctx, "linked %s account %s to user %d", providerName, claims.Subject, user.ID)
+	return user, nil
+}
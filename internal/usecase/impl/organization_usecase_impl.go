@@ -0,0 +1,230 @@
+package impl
+
+import (
+	"context"
+	"errors"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// permissionManageOrganizations lets platform operators manage organizations and read across them
+const permissionManageOrganizations = "organizations:manage"
+
+type organizationUsecase struct {
+	organizationRepo repository.OrganizationRepository
+	userRepo         repository.UserRepository
+	txManager        database.Transactor
+	audit            *auditRecorder
+	cache            *userCache
+	logger           *logger.Logger
+}
+
+func NewOrganizationUsecase(
+	organizationRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	userCacheRepo repository.UserCacheRepository,
+	txManager database.Transactor,
+	log *logger.Logger,
+) usecase.OrganizationUsecase {
+	return &organizationUsecase{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+		txManager:        txManager,
+		audit:            newAuditRecorder(auditLogRepo),
+		cache:            newUserCache(userCacheRepo, log),
+		logger:           log,
+	}
+}
+
+func (u *organizationUsecase) CreateOrganization(ctx context.Context, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error) {
+	organization := &model.Organization{
+		Name: req.Name,
+	}
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.organizationRepo.Create(txCtx, organization); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionOrganizationCreated, model.AuditEntityOrganization, organization.ID, model.AuditChanges{
+			"name": {After: organization.Name},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := organization.ToResponse()
+	return &response, nil
+}
+
+func (u *organizationUsecase) GetOrganization(ctx context.Context, id int64) (*model.OrganizationResponse, error) {
+	organization, err := u.getOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := organization.ToResponse()
+	return &response, nil
+}
+
+func (u *organizationUsecase) GetAllOrganizations(ctx context.Context, pagination utils.PaginationParams, sort []utils.SortParams) ([]model.OrganizationResponse, int64, error) {
+	var (
+		organizations []model.Organization
+		total         int64
+	)
+
+	// Run GetAll and Count concurrently
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		organizations, err = u.organizationRepo.GetAll(ctx, pagination, sort)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		total, err = u.organizationRepo.Count(ctx)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	return toOrganizationResponses(organizations), total, nil
+}
+
+func (u *organizationUsecase) GetUserOrganizations(ctx context.Context, userID int64) ([]model.OrganizationResponse, error) {
+	organizations, err := u.organizationRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganizationResponses(organizations), nil
+}
+
+func (u *organizationUsecase) AddMember(ctx context.Context, organizationID, userID int64) error {
+	if _, err := u.getOrganization(ctx, organizationID); err != nil {
+		return err
+	}
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return usecase.ErrUserNotFound
+		}
+		return err
+	}
+
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		added, err := u.organizationRepo.AddMember(txCtx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if !added {
+			return usecase.ErrAlreadyOrganizationMember
+		}
+
+		return u.audit.record(txCtx, model.AuditActionOrganizationMemberAdded, model.AuditEntityOrganization, organizationID, model.AuditChanges{
+			"user_id": {After: userID},
+		})
+	})
+}
+
+func (u *organizationUsecase) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	if _, err := u.getOrganization(ctx, organizationID); err != nil {
+		return err
+	}
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.organizationRepo.RemoveMember(txCtx, organizationID, userID); err != nil {
+			if errors.Is(err, repository.ErrMembershipNotFound) {
+				return usecase.ErrOrganizationMemberNotFound
+			}
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionOrganizationMemberRemoved, model.AuditEntityOrganization, organizationID, model.AuditChanges{
+			"user_id": {Before: userID},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// The response of the user cached for the organization must not outlive the membership
+	u.cache.invalidate(ctx, userID)
+	return nil
+}
+
+// ResolveOrganization lets holders of organizations:manage select any existing organization,
+// users only the organizations they are a member of and API keys only their own organization.
+// Membership is checked on every request, so removing a member takes effect before their token expires.
+func (u *organizationUsecase) ResolveOrganization(ctx context.Context, claims *auth.Claims, requestedID int64) (int64, error) {
+	canManage := claims.HasPermission(permissionManageOrganizations)
+
+	organizationID := requestedID
+	if organizationID == 0 {
+		// Platform operators see every organization unless they select one
+		if canManage {
+			return 0, nil
+		}
+		if claims.OrganizationID == 0 {
+			return 0, usecase.ErrOrganizationRequired
+		}
+		organizationID = claims.OrganizationID
+	}
+
+	if canManage {
+		if _, err := u.getOrganization(ctx, organizationID); err != nil {
+			return 0, err
+		}
+		return organizationID, nil
+	}
+
+	// API keys only act in the organization they were created in
+	if claims.UserID == 0 {
+		if organizationID != claims.OrganizationID {
+			return 0, usecase.ErrNotOrganizationMember
+		}
+		return organizationID, nil
+	}
+
+	isMember, err := u.organizationRepo.IsMember(ctx, organizationID, claims.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if !isMember {
+		return 0, usecase.ErrNotOrganizationMember
+	}
+
+	return organizationID, nil
+}
+
+func (u *organizationUsecase) getOrganization(ctx context.Context, id int64) (*model.Organization, error) {
+	organization, err := u.organizationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return nil, usecase.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+func toOrganizationResponses(organizations []model.Organization) []model.OrganizationResponse {
+	responses := make([]model.OrganizationResponse, len(organizations))
+	for i, organization := range organizations {
+		responses[i] = organization.ToResponse()
+	}
+	return responses
+}
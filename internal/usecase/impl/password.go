@@ -0,0 +1,35 @@
+package impl
+
+import (
+	"fmt"
+
+	"go-gin-sqlx-template/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordCost returns the configured bcrypt cost, or bcrypt.DefaultCost when unset
+func passwordCost(cfg config.Config) int {
+	if cfg.BcryptCost <= 0 {
+		return bcrypt.DefaultCost
+	}
+	return cfg.BcryptCost
+}
+
+// hashPassword hashes the password with the configured bcrypt cost
+func hashPassword(cfg config.Config, password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost(cfg))
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashed), nil
+}
+
+// needsRehash reports whether the hash was generated with a lower cost than the configured one
+func needsRehash(cfg config.Config, hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false
+	}
+	return cost < passwordCost(cfg)
+}
@@ -0,0 +1,150 @@
+package impl
+
+import (
+	"context"
+	"errors"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+type roleUsecase struct {
+	roleRepo  repository.RoleRepository
+	userRepo  repository.UserRepository
+	txManager database.Transactor
+	audit     *auditRecorder
+	logger    *logger.Logger
+}
+
+func NewRoleUsecase(
+	roleRepo repository.RoleRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	txManager database.Transactor,
+	log *logger.Logger,
+) usecase.RoleUsecase {
+	return &roleUsecase{
+		roleRepo:  roleRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+		audit:     newAuditRecorder(auditLogRepo),
+		logger:    log,
+	}
+}
+
+func (u *roleUsecase) GetAllRoles(ctx context.Context) ([]model.RoleResponse, error) {
+	roles, err := u.roleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toResponses(ctx, roles)
+}
+
+func (u *roleUsecase) GetUserRoles(ctx context.Context, userID int64) ([]model.RoleResponse, error) {
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	roles, err := u.roleRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toResponses(ctx, roles)
+}
+
+// AssignRole only assigns roles whose permissions the caller holds,
+// so roles:assign never grants more than its holder already has
+func (u *roleUsecase) AssignRole(ctx context.Context, userID int64, roleName string) error {
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := u.userRepo.GetByID(txCtx, userID); err != nil {
+			return err
+		}
+
+		role, err := u.roleRepo.GetByName(txCtx, roleName)
+		if err != nil {
+			if errors.Is(err, repository.ErrRoleNotFound) {
+				return usecase.ErrRoleNotFound
+			}
+			return err
+		}
+		if err := u.checkRoleHeld(txCtx, role); err != nil {
+			return err
+		}
+
+		if err := u.roleRepo.AssignToUser(txCtx, userID, role.ID); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserRoleAssigned, model.AuditEntityUser, userID, model.AuditChanges{
+			"role": {After: role.Name},
+		})
+	})
+}
+
+// RemoveRole follows the rule of AssignRole, so a caller cannot demote users holding more than they do
+func (u *roleUsecase) RemoveRole(ctx context.Context, userID int64, roleName string) error {
+	role, err := u.roleRepo.GetByName(ctx, roleName)
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return usecase.ErrRoleNotFound
+		}
+		return err
+	}
+	if err := u.checkRoleHeld(ctx, role); err != nil {
+		return err
+	}
+
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// The lookup is scoped to the tenant, users of other organizations are not found
+		if _, err := u.userRepo.GetByID(txCtx, userID); err != nil {
+			return err
+		}
+
+		if err := u.roleRepo.RemoveFromUser(txCtx, userID, role.ID); err != nil {
+			return err
+		}
+
+		return u.audit.record(txCtx, model.AuditActionUserRoleRemoved, model.AuditEntityUser, userID, model.AuditChanges{
+			"role": {Before: role.Name},
+		})
+	})
+}
+
+// checkRoleHeld returns ErrRoleNotHeld unless the caller holds every permission of the role
+func (u *roleUsecase) checkRoleHeld(ctx context.Context, role *model.Role) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return usecase.ErrRoleNotHeld
+	}
+
+	permissions, err := u.roleRepo.GetPermissionsByRoleID(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !claims.HasPermission(permission) {
+			return usecase.ErrRoleNotHeld
+		}
+	}
+	return nil
+}
+
+// toResponses attaches the permissions of each role
+func (u *roleUsecase) toResponses(ctx context.Context, roles []model.Role) ([]model.RoleResponse, error) {
+	responses := make([]model.RoleResponse, len(roles))
+	for i, role := range roles {
+		permissions, err := u.roleRepo.GetPermissionsByRoleID(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = role.ToResponse(permissions)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,108 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+)
+
+// assignableRoleRepository has an admin and a user role and records the assignments of user 1
+type assignableRoleRepository struct {
+	fakeRoleRepository
+
+	assigned []int64
+}
+
+var assignableRoles = map[string]struct {
+	role        model.Role
+	permissions []string
+}{
+	"admin": {model.Role{ID: 1, Name: "admin"}, []string{"users:read", "users:delete", "roles:assign"}},
+	"user":  {model.Role{ID: 2, Name: "user"}, nil},
+}
+
+func (r *assignableRoleRepository) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	role, ok := assignableRoles[name]
+	if !ok {
+		return nil, repository.ErrRoleNotFound
+	}
+	return &role.role, nil
+}
+
+func (r *assignableRoleRepository) GetPermissionsByRoleID(ctx context.Context, roleID int64) ([]string, error) {
+	for _, role := range assignableRoles {
+		if role.role.ID == roleID {
+			return role.permissions, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *assignableRoleRepository) AssignToUser(ctx context.Context, userID, roleID int64) error {
+	r.assigned = append(r.assigned, roleID)
+	return nil
+}
+
+func (r *assignableRoleRepository) RemoveFromUser(ctx context.Context, userID, roleID int64) error {
+	r.assigned = slices.DeleteFunc(r.assigned, func(id int64) bool { return id == roleID })
+	return nil
+}
+
+func TestAssignRoleRequiresItsPermissions(t *testing.T) {
+	userManager := &auth.Claims{UserID: 2, Permissions: []string{"users:read", "roles:assign"}}
+	admin := &auth.Claims{UserID: 3, Permissions: []string{"users:read", "users:delete", "roles:assign"}}
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		role   string
+		want   error
+	}{
+		{"role without permissions", userManager, "user", nil},
+		{"role with permissions the caller lacks", userManager, "admin", usecase.ErrRoleNotHeld},
+		{"role with permissions the caller holds", admin, "admin", nil},
+		{"no caller", nil, "user", usecase.ErrRoleNotHeld},
+		{"unknown role", admin, "owner", usecase.ErrRoleNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := &assignableRoleRepository{}
+			auditLog := &fakeAuditLogRepository{}
+			u := NewRoleUsecase(roleRepo, newFakeUserRepository(&model.User{ID: 1}), auditLog, fakeTransactor{}, testLogger)
+
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.WithClaims(ctx, tt.claims)
+			}
+
+			if err := u.AssignRole(ctx, 1, tt.role); !errors.Is(err, tt.want) {
+				t.Fatalf("AssignRole = %v, want %v", err, tt.want)
+			}
+			if assigned := len(roleRepo.assigned) == 1; assigned != (tt.want == nil) {
+				t.Errorf("assigned roles = %v", roleRepo.assigned)
+			}
+			if logged := len(auditLog.actions()) == 1; logged != (tt.want == nil) {
+				t.Errorf("audit actions = %v", auditLog.actions())
+			}
+		})
+	}
+}
+
+func TestRemoveRoleRequiresItsPermissions(t *testing.T) {
+	roleRepo := &assignableRoleRepository{assigned: []int64{1}}
+	u := NewRoleUsecase(roleRepo, newFakeUserRepository(&model.User{ID: 1}), &fakeAuditLogRepository{}, fakeTransactor{}, testLogger)
+
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{UserID: 2, Permissions: []string{"roles:assign"}})
+	if err := u.RemoveRole(ctx, 1, "admin"); !errors.Is(err, usecase.ErrRoleNotHeld) {
+		t.Fatalf("RemoveRole = %v, want ErrRoleNotHeld", err)
+	}
+	if len(roleRepo.assigned) != 1 {
+		t.Error("the admin role was removed")
+	}
+}
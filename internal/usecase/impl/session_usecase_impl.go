@@ -0,0 +1,125 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+type sessionUsecase struct {
+	sessionRepo repository.SessionRepository
+	userRepo    repository.UserRepository
+	txManager   database.Transactor
+	audit       *auditRecorder
+	logger      *logger.Logger
+}
+
+func NewSessionUsecase(
+	sessionRepo repository.SessionRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	txManager database.Transactor,
+	log *logger.Logger,
+) usecase.SessionUsecase {
+	return &sessionUsecase{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		txManager:   txManager,
+		audit:       newAuditRecorder(auditLogRepo),
+		logger:      log,
+	}
+}
+
+// ListSessions returns the active sessions of the user, most recently used first
+func (u *sessionUsecase) ListSessions(ctx context.Context, userID int64, currentSessionID string) ([]model.SessionResponse, error) {
+	sessions, err := u.sessionRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(sessions, func(a, b model.Session) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+
+	responses := make([]model.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = session.ToResponse(currentSessionID)
+	}
+
+	return responses, nil
+}
+
+// RevokeSession signs the user out of one device. Sessions of other users are
+// reported as not found so their IDs cannot be probed.
+func (u *sessionUsecase) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	session, err := u.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return usecase.ErrSessionNotFound
+		}
+		return err
+	}
+	if session.UserID != userID {
+		return usecase.ErrSessionNotFound
+	}
+
+	// Sessions live in Redis, the audit log is rolled back if revoking fails
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.audit.record(txCtx, model.AuditActionUserSessionRevoked, model.AuditEntityUser, userID, model.AuditChanges{
+			"session_id": {Before: sessionID},
+		}); err != nil {
+			return err
+		}
+
+		return u.sessionRepo.RevokeFamily(txCtx, sessionID)
+	})
+}
+
+// RevokeAllSessions signs the user out of every device
+func (u *sessionUsecase) RevokeAllSessions(ctx context.Context, userID int64) error {
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	// Sessions live in Redis, the audit log is rolled back if revoking fails
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.audit.record(txCtx, model.AuditActionUserSessionsRevoked, model.AuditEntityUser, userID, nil); err != nil {
+			return err
+		}
+
+		return u.sessionRepo.RevokeAllForUser(txCtx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.logger.Infof(ctx, "all sessions revoked for user %d", userID)
+	return nil
+}
+
+// ValidateSession checks that the session of the access token is still active and
+// records the activity. Tokens without a session, issued to API keys or before
+// sessions were tracked, are accepted until they expire.
+func (u *sessionUsecase) ValidateSession(ctx context.Context, claims *auth.Claims) error {
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	active, err := u.sessionRepo.TouchSession(ctx, claims.SessionID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !active {
+		return usecase.ErrSessionRevoked
+	}
+
+	return nil
+}
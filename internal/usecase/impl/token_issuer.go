@@ -0,0 +1,259 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/tenant"
+)
+
+const (
+	defaultRefreshTokenTTL = 7 * 24 * time.Hour
+	defaultMFAChallengeTTL = 5 * time.Minute
+)
+
+// tokenIssuer turns an authenticated user into a session and token pair.
+// It is shared by the password login (auth usecase) and external identity
+// provider logins (OIDC usecase), so both honor 2FA and session tracking alike.
+type tokenIssuer struct {
+	roleRepo         repository.RoleRepository
+	organizationRepo repository.OrganizationRepository
+	sessionRepo      repository.SessionRepository
+	mfaRepo          repository.MFARepository
+	mfaChallengeRepo repository.MFAChallengeRepository
+	jwtManager       *auth.JWTManager
+	config           config.Config
+}
+
+func newTokenIssuer(
+	roleRepo repository.RoleRepository,
+	organizationRepo repository.OrganizationRepository,
+	sessionRepo repository.SessionRepository,
+	mfaRepo repository.MFARepository,
+	mfaChallengeRepo repository.MFAChallengeRepository,
+	jwtManager *auth.JWTManager,
+	cfg config.Config,
+) *tokenIssuer {
+	return &tokenIssuer{
+		roleRepo:         roleRepo,
+		organizationRepo: organizationRepo,
+		sessionRepo:      sessionRepo,
+		mfaRepo:          mfaRepo,
+		mfaChallengeRepo: mfaChallengeRepo,
+		jwtManager:       jwtManager,
+		config:           cfg,
+	}
+}
+
+// completeLogin finishes the first login step of an authenticated user. Accounts
+// with 2FA enabled receive an MFA challenge, everyone else a new session.
+func (t *tokenIssuer) completeLogin(ctx context.Context, user *model.User, client model.ClientInfo) (*model.LoginResponse, error) {
+	mfa, err := t.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, repository.ErrMFANotFound) {
+		return nil, err
+	}
+	if mfa != nil && mfa.IsEnabled() {
+		return t.createMFAChallenge(ctx, user.ID)
+	}
+
+	token, err := t.startSession(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LoginResponse{TokenResponse: token}, nil
+}
+
+// createMFAChallenge stores a new login challenge for the user and returns its token
+func (t *tokenIssuer) createMFAChallenge(ctx context.Context, userID int64) (*model.LoginResponse, error) {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := t.config.MFAChallengeTTL
+	if ttl <= 0 {
+		ttl = defaultMFAChallengeTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	err = t.mfaChallengeRepo.Create(ctx, &model.MFAChallenge{
+		TokenHash: auth.HashToken(token),
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LoginResponse{
+		MFARequired:  true,
+		MFAToken:     token,
+		MFAExpiresAt: &expiresAt,
+	}, nil
+}
+
+// startSession records a new signed-in device and issues its first token pair.
+// Every login starts a new refresh token family, whose ID doubles as the session ID.
+func (t *tokenIssuer) startSession(ctx context.Context, user *model.User, client model.ClientInfo) (*model.TokenResponse, error) {
+	familyID, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL := t.config.RefreshTokenTTL
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenTTL
+	}
+
+	now := time.Now()
+	err = t.sessionRepo.CreateSession(ctx, &model.Session{
+		ID:         familyID,
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(refreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.issueTokens(ctx, user, familyID)
+}
+
+// startImpersonation records a session of the user for the impersonating admin and issues
+// its access token with the session ID. The session lives as long as the token, there is no refresh token.
+func (t *tokenIssuer) startImpersonation(ctx context.Context, user *model.User, impersonatorID int64, client model.ClientInfo, ttl time.Duration) (*model.TokenResponse, string, error) {
+	sessionID, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	claims, err := t.buildClaims(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+	claims.SessionID = sessionID
+	claims.ImpersonatorID = impersonatorID
+	// Stay in the organization the admin impersonates from
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		claims.OrganizationID = organizationID
+	}
+
+	now := time.Now()
+	err = t.sessionRepo.CreateSession(ctx, &model.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	accessToken, expiresAt, err := t.jwtManager.GenerateWithTTL(*claims, ttl)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	return &model.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+		ExpiresAt:   expiresAt,
+	}, sessionID, nil
+}
+
+// issueTokens signs a new access token and stores a new refresh token in the given family.
+// Roles and permissions are loaded on every issue, so changes take effect on the next refresh.
+func (t *tokenIssuer) issueTokens(ctx context.Context, user *model.User, familyID string) (*model.TokenResponse, error) {
+	claims, err := t.buildClaims(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	claims.SessionID = familyID
+
+	accessToken, expiresAt, err := t.jwtManager.Generate(*claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL := t.config.RefreshTokenTTL
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenTTL
+	}
+
+	now := time.Now()
+	refreshExpiresAt := now.Add(refreshTTL)
+
+	err = t.sessionRepo.Save(ctx, &model.RefreshToken{
+		TokenHash: auth.HashToken(refreshToken),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		CreatedAt: now,
+		ExpiresAt: refreshExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(t.jwtManager.TTL().Seconds()),
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: &refreshExpiresAt,
+	}, nil
+}
+
+// buildClaims loads the user's roles, permissions and first organization into access token claims
+func (t *tokenIssuer) buildClaims(ctx context.Context, user *model.User) (*auth.Claims, error) {
+	roles, err := t.roleRepo.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := t.roleRepo.GetUserPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := t.organizationRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	var organizationID int64
+	if len(organizations) > 0 {
+		organizationID = organizations[0].ID
+	}
+
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = role.Name
+	}
+
+	return &auth.Claims{
+		UserID:         user.ID,
+		Email:          user.Email,
+		Roles:          roleNames,
+		Permissions:    permissions,
+		OrganizationID: organizationID,
+	}, nil
+}
@@ -0,0 +1,151 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// BatchUsers applies the operations in order. Notifications of applied operations
+// are only sent once they are committed, so a rolled back batch announces nothing.
+// The cached responses of changed users are dropped and deleted users signed out at the same time.
+func (u *userUsecase) BatchUsers(ctx context.Context, req model.BatchUserRequest) []usecase.BatchUserResult {
+	results := make([]usecase.BatchUserResult, len(req.Operations))
+
+	if !req.Atomic {
+		for i, op := range req.Operations {
+			user, notify, err := u.applyBatchOperation(ctx, op)
+			results[i] = usecase.BatchUserResult{User: user, Err: err}
+			if err == nil && notify != nil {
+				notify(ctx)
+			}
+		}
+		return results
+	}
+
+	var notifications []func(ctx context.Context)
+	failed := -1
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		for i, op := range req.Operations {
+			user, notify, err := u.applyBatchOperation(txCtx, op)
+			if err != nil {
+				failed = i
+				return err
+			}
+			results[i].User = user
+			if notify != nil {
+				notifications = append(notifications, notify)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for i := range results {
+			switch {
+			case i == failed:
+				results[i] = usecase.BatchUserResult{Err: err}
+			case failed == -1:
+				// The commit itself failed
+				results[i] = usecase.BatchUserResult{Err: err}
+			default:
+				results[i] = usecase.BatchUserResult{Err: usecase.ErrBatchRolledBack}
+			}
+		}
+		return results
+	}
+
+	for _, notify := range notifications {
+		notify(ctx)
+	}
+	return results
+}
+
+// applyBatchOperation applies one operation and returns the user with the notification to send once it is committed
+func (u *userUsecase) applyBatchOperation(ctx context.Context, op model.BatchUserOperation) (*model.UserResponse, func(ctx context.Context), error) {
+	ifMatch := utils.ParseIfMatch(op.IfMatch)
+
+	switch op.Op {
+	case model.BatchOpCreate:
+		var req model.CreateUserRequest
+		if err := decodeBatchData(op.Data, &req); err != nil {
+			return nil, nil, err
+		}
+
+		user, err := u.createUser(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		response := user.ToResponse()
+		return &response, func(ctx context.Context) { u.notifyUserCreated(ctx, user) }, nil
+
+	case model.BatchOpUpdate:
+		var req model.PatchUserRequest
+		if err := decodeBatchData(op.Data, &req); err != nil {
+			return nil, nil, err
+		}
+		email, name, err := patchValues(req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		user, err := u.userRepo.GetByID(ctx, op.ID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return nil, nil, usecase.ErrUserNotFound
+			}
+			return nil, nil, err
+		}
+		if err := u.checkPrecondition(user, ifMatch); err != nil {
+			return nil, nil, err
+		}
+
+		columns, err := u.updateUser(ctx, user, email, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		response := user.ToResponse()
+		return &response, func(ctx context.Context) { u.notifyUserUpdated(ctx, user, columns) }, nil
+
+	case model.BatchOpDelete:
+		user, err := u.userRepo.GetByID(ctx, op.ID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return nil, nil, usecase.ErrUserNotFound
+			}
+			return nil, nil, err
+		}
+		if err := u.deleteUser(ctx, user, ifMatch); err != nil {
+			return nil, nil, err
+		}
+		return nil, func(ctx context.Context) { u.notifyUserDeleted(ctx, user) }, nil
+
+	default:
+		return nil, nil, apperror.Detail(usecase.ErrInvalidBatchOperation, fmt.Sprintf("unknown op %q", op.Op))
+	}
+}
+
+// decodeBatchData decodes the data of an operation and validates it with the rules of the single endpoint
+func decodeBatchData(data json.RawMessage, dst any) error {
+	if len(data) == 0 {
+		return apperror.Detail(usecase.ErrInvalidBatchOperation, "data is required")
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("%w: %v", apperror.Detail(usecase.ErrInvalidBatchOperation, "data is not valid JSON"), err)
+	}
+	// Keep the validator errors in the chain, they become the field errors of the result
+	if err := binding.Validator.ValidateStruct(dst); err != nil {
+		return fmt.Errorf("%w: %w", usecase.ErrInvalidBatchOperation, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/repository/memory"
+	"go-gin-sqlx-template/internal/usecase"
+)
+
+type batchTestEnv struct {
+	usecase     usecase.UserUsecase
+	userRepo    *fakeUserRepository
+	sessionRepo repository.SessionRepository
+	cache       *fakeUserCacheRepository
+}
+
+// newBatchTestEnv has user 1 signed in on one device
+func newBatchTestEnv(t *testing.T) *batchTestEnv {
+	t.Helper()
+
+	env := &batchTestEnv{
+		userRepo:    newFakeUserRepository(&model.User{ID: 1, Email: "jane@example.com", Version: 1}),
+		sessionRepo: memory.NewSessionRepository(),
+		cache:       &fakeUserCacheRepository{},
+	}
+	now := time.Now()
+	err := env.sessionRepo.CreateSession(context.Background(), &model.Session{ID: "family", UserID: 1, CreatedAt: now, LastSeenAt: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env.usecase = NewUserUsecase(env.userRepo, nil, env.sessionRepo, &fakeAuditLogRepository{}, env.cache, fakeTransactor{}, nil, nil, config.Config{}, testLogger)
+	return env
+}
+
+func (env *batchTestEnv) sessions(t *testing.T) int {
+	t.Helper()
+
+	sessions, err := env.sessionRepo.ListSessions(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(sessions)
+}
+
+func TestAtomicBatchDeleteSignsOutAfterCommit(t *testing.T) {
+	env := newBatchTestEnv(t)
+
+	results := env.usecase.BatchUsers(context.Background(), model.BatchUserRequest{
+		Atomic: true,
+		Operations: []model.BatchUserOperation{
+			{Op: model.BatchOpDelete, ID: 1},
+		},
+	})
+	if results[0].Err != nil {
+		t.Fatal(results[0].Err)
+	}
+
+	if n := env.sessions(t); n != 0 {
+		t.Errorf("got %d sessions after the delete, want none", n)
+	}
+	if len(env.cache.invalidated) != 1 {
+		t.Errorf("invalidated = %v, want user 1", env.cache.invalidated)
+	}
+}
+
+func TestRolledBackBatchDeleteKeepsSessions(t *testing.T) {
+	env := newBatchTestEnv(t)
+
+	results := env.usecase.BatchUsers(context.Background(), model.BatchUserRequest{
+		Atomic: true,
+		Operations: []model.BatchUserOperation{
+			{Op: model.BatchOpDelete, ID: 1},
+			{Op: model.BatchOpDelete, ID: 99},
+		},
+	})
+	if !errors.Is(results[0].Err, usecase.ErrBatchRolledBack) || !errors.Is(results[1].Err, usecase.ErrUserNotFound) {
+		t.Fatalf("results = %+v, want the batch rolled back by the second operation", results)
+	}
+
+	if n := env.sessions(t); n != 1 {
+		t.Errorf("got %d sessions after the rollback, want 1", n)
+	}
+	if len(env.cache.invalidated) != 0 {
+		t.Errorf("invalidated = %v, want none", env.cache.invalidated)
+	}
+}
@@ -0,0 +1,29 @@
+package impl
+
+import (
+	"context"
+
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/pkg/logger"
+)
+
+// userCache drops the cached response of a user after every committed change that
+// bumps its version, so the cache never serves a body or ETag of an older version
+type userCache struct {
+	userCacheRepo repository.UserCacheRepository
+	logger        *logger.Logger
+}
+
+func newUserCache(userCacheRepo repository.UserCacheRepository, log *logger.Logger) *userCache {
+	return &userCache{
+		userCacheRepo: userCacheRepo,
+		logger:        log,
+	}
+}
+
+// invalidate only logs failures, the change is committed and the cached response expires on its own
+func (c *userCache) invalidate(ctx context.Context, userID int64) {
+	if err := c.userCacheRepo.Invalidate(ctx, userID); err != nil {
+		c.logger.Errorf(ctx, "failed to delete cache: %v", err)
+	}
+}
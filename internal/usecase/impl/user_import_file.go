@@ -0,0 +1,172 @@
+package impl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go-gin-sqlx-template/internal/apperror"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/validation"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// importRow is a row of an import file with the reasons it cannot be imported
+type importRow struct {
+	row    int
+	req    model.CreateUserRequest
+	errors []string
+}
+
+// parseImportFile reads the rows of a CSV or NDJSON import file.
+// Rows that cannot be read are returned with errors, a file that cannot be read
+// at all returns an error wrapping usecase.ErrInvalidImportFile.
+func parseImportFile(format string, data []byte, maxRows int) ([]importRow, error) {
+	var (
+		rows []importRow
+		err  error
+	)
+	switch format {
+	case model.UserImportFormatCSV:
+		rows, err = parseImportCSV(data, maxRows)
+	case model.UserImportFormatNDJSON:
+		rows, err = parseImportNDJSON(data, maxRows)
+	default:
+		return nil, usecase.ErrUnsupportedImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, apperror.Detail(usecase.ErrInvalidImportFile, "file has no rows")
+	}
+
+	for i := range rows {
+		if len(rows[i].errors) == 0 {
+			rows[i].errors = validateImportRow(rows[i].req)
+		}
+	}
+
+	return rows, nil
+}
+
+// parseImportCSV reads a CSV file whose header names the email, name and password columns
+func parseImportCSV(data []byte, maxRows int) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	// Short rows are reported per row instead of failing the whole file
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, apperror.Detail(usecase.ErrInvalidImportFile, "file is empty")
+		}
+		return nil, fmt.Errorf("%w: %v", apperror.Detail(usecase.ErrInvalidImportFile, "file is not valid CSV"), err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"email", "name", "password"} {
+		if _, ok := columns[name]; !ok {
+			return nil, apperror.Detail(usecase.ErrInvalidImportFile, "csv header must contain email, name and password")
+		}
+	}
+
+	column := func(record []string, name string) string {
+		if i := columns[name]; i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", apperror.Detail(usecase.ErrInvalidImportFile, "file is not valid CSV"), err)
+		}
+		if len(rows) == maxRows {
+			return nil, apperror.Detail(usecase.ErrInvalidImportFile, fmt.Sprintf("file has more than %d rows", maxRows))
+		}
+
+		rows = append(rows, importRow{
+			row: len(rows) + 1,
+			req: model.CreateUserRequest{
+				Email:    strings.TrimSpace(column(record, "email")),
+				Name:     strings.TrimSpace(column(record, "name")),
+				Password: column(record, "password"),
+			},
+		})
+	}
+
+	return rows, nil
+}
+
+// parseImportNDJSON reads a file with one CreateUserRequest JSON object per line.
+// Blank lines are skipped but still counted, so row numbers match line numbers.
+func parseImportNDJSON(data []byte, maxRows int) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+
+	var (
+		rows []importRow
+		line int
+	)
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, apperror.Detail(usecase.ErrInvalidImportFile, fmt.Sprintf("file has more than %d rows", maxRows))
+		}
+
+		row := importRow{row: line}
+		if err := json.Unmarshal(text, &row.req); err != nil {
+			row.errors = []string{"invalid JSON"}
+		}
+		row.req.Email = strings.TrimSpace(row.req.Email)
+		row.req.Name = strings.TrimSpace(row.req.Name)
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", usecase.ErrInvalidImportFile, err)
+	}
+
+	return rows, nil
+}
+
+// validateImportRow applies the same rules as POST /users
+func validateImportRow(req model.CreateUserRequest) []string {
+	err := binding.Validator.ValidateStruct(&req)
+	if err == nil {
+		return nil
+	}
+
+	fields := validation.FieldErrors(err, validation.DefaultLocale)
+	if fields == nil {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+	return messages
+}
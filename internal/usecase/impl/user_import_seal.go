@@ -0,0 +1,60 @@
+package impl
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// importKeySize selects AES-256 for sealed import files
+const importKeySize = 32
+
+// sealImportFile encrypts an uploaded import file with a new random key. The sealed
+// file is stored with the import and the key only travels in the task payload, so
+// the passwords of the file are never readable from the database alone.
+func sealImportFile(data []byte) (sealed, key []byte, err error) {
+	key = make([]byte, importKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate import key: %w", err)
+	}
+
+	gcm, err := importCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate import nonce: %w", err)
+	}
+
+	// The nonce is stored in front of the ciphertext
+	return gcm.Seal(nonce, nonce, data, nil), key, nil
+}
+
+// openImportFile decrypts a file sealed by sealImportFile
+func openImportFile(sealed, key []byte) ([]byte, error) {
+	gcm, err := importCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("sealed import file is too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	data, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	return data, nil
+}
+
+func importCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
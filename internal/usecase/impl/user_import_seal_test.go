@@ -0,0 +1,37 @@
+package impl
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealImportFile(t *testing.T) {
+	data := []byte("email,name,password\njane@example.com,Jane,Str0ng!Password\n")
+
+	sealed, key, err := sealImportFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, []byte("Str0ng!Password")) {
+		t.Fatal("sealed file contains the plaintext password")
+	}
+
+	opened, err := openImportFile(sealed, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(opened, data) {
+		t.Errorf("openImportFile = %q, want %q", opened, data)
+	}
+
+	_, otherKey, err := sealImportFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openImportFile(sealed, otherKey); err == nil {
+		t.Error("openImportFile with another key succeeded")
+	}
+	if _, err := openImportFile(sealed[:4], key); err == nil {
+		t.Error("openImportFile with a truncated file succeeded")
+	}
+}
@@ -0,0 +1,326 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/internal/repository"
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/internal/worker"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/database"
+	"go-gin-sqlx-template/pkg/logger"
+	"go-gin-sqlx-template/pkg/tenant"
+
+	"github.com/hibiken/asynq"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultUserImportMaxBytes  = 10 << 20
+	defaultUserImportMaxRows   = 10000
+	defaultUserImportBatchSize = 500
+	defaultUserImportRetention = 24 * time.Hour
+)
+
+type userImportUsecase struct {
+	userRepo           repository.UserRepository
+	userImportRepo     repository.UserImportRepository
+	auditLogRepo       repository.AuditLogRepository
+	txManager          database.Transactor
+	asynqClient        *asynq.Client
+	verificationSender *emailVerificationSender
+	config             config.Config
+	logger             *logger.Logger
+}
+
+func NewUserImportUsecase(
+	userRepo repository.UserRepository,
+	userImportRepo repository.UserImportRepository,
+	emailVerificationRepo repository.EmailVerificationRepository,
+	auditLogRepo repository.AuditLogRepository,
+	txManager database.Transactor,
+	asynqClient *asynq.Client,
+	cfg config.Config,
+	log *logger.Logger,
+) usecase.UserImportUsecase {
+	return &userImportUsecase{
+		userRepo:           userRepo,
+		userImportRepo:     userImportRepo,
+		auditLogRepo:       auditLogRepo,
+		txManager:          txManager,
+		asynqClient:        asynqClient,
+		verificationSender: newEmailVerificationSender(emailVerificationRepo, asynqClient, cfg, log),
+		config:             cfg,
+		logger:             log,
+	}
+}
+
+func (u *userImportUsecase) CreateImport(ctx context.Context, filename, format string, data []byte) (*model.UserImportResponse, error) {
+	if int64(len(data)) > u.MaxBytes() {
+		return nil, usecase.ErrImportTooLarge
+	}
+
+	// Reject unreadable files now instead of failing in the worker,
+	// rows are validated again when the import is processed
+	if _, err := parseImportFile(format, data, u.maxRows()); err != nil {
+		return nil, err
+	}
+
+	// The file holds plaintext passwords, only its sealed form is stored
+	sealed, key, err := sealImportFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	userImport := &model.UserImport{
+		Format:   format,
+		Filename: filename,
+		Data:     sealed,
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); ok && userID != 0 {
+		userImport.CreatedBy = &userID
+	}
+	if organizationID, ok := tenant.OrganizationIDFromContext(ctx); ok {
+		userImport.OrganizationID = &organizationID
+	}
+
+	if err := u.userImportRepo.Create(ctx, userImport); err != nil {
+		return nil, err
+	}
+
+	task, err := worker.NewUserImportTask(ctx, userImport.ID, key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := u.asynqClient.Enqueue(task)
+	if err != nil {
+		message := "failed to enqueue import"
+		if finishErr := u.userImportRepo.Finish(ctx, userImport.ID, model.UserImportStatusFailed, &message); finishErr != nil {
+			u.logger.Errorf(ctx, "Failed to mark user import as failed: %v", finishErr)
+		}
+		return nil, fmt.Errorf("failed to enqueue user import task: %w", err)
+	}
+	u.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
+	response := userImport.ToResponse()
+	return &response, nil
+}
+
+func (u *userImportUsecase) GetImport(ctx context.Context, id int64) (*model.UserImportResponse, error) {
+	userImport, err := u.userImportRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserImportNotFound) {
+			return nil, usecase.ErrUserImportNotFound
+		}
+		return nil, err
+	}
+
+	response := userImport.ToResponse()
+	return &response, nil
+}
+
+// ProcessImport creates the users of the file in batches, each batch and its progress
+// in one transaction. A retried task resumes after the last committed batch.
+func (u *userImportUsecase) ProcessImport(ctx context.Context, id int64, key []byte) error {
+	userImport, err := u.userImportRepo.Start(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserImportFinished) {
+			u.logger.Infof(ctx, "User import %d already finished", id)
+			return nil
+		}
+		return err
+	}
+
+	data := userImport.Data
+	// Imports enqueued before files were sealed carry no key
+	if key != nil {
+		if data, err = openImportFile(userImport.Data, key); err != nil {
+			return u.FailImport(ctx, id, "import file could not be read")
+		}
+	}
+
+	rows, err := parseImportFile(userImport.Format, data, u.maxRows())
+	if err != nil {
+		return u.FailImport(ctx, id, err.Error())
+	}
+	// The users join the organization the file was uploaded to
+	if userImport.OrganizationID != nil {
+		ctx = tenant.WithOrganizationID(ctx, *userImport.OrganizationID)
+	}
+	markDuplicateEmails(rows)
+	userImport.TotalRows = len(rows)
+
+	batchSize := u.config.UserImportBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultUserImportBatchSize
+	}
+
+	for start := userImport.ProcessedRows; start < len(rows); start += batchSize {
+		batch := rows[start:min(start+batchSize, len(rows))]
+		if err := u.processBatch(ctx, userImport, batch); err != nil {
+			return err
+		}
+	}
+
+	if err := u.userImportRepo.Finish(ctx, id, model.UserImportStatusCompleted, nil); err != nil {
+		return err
+	}
+
+	u.logger.Infof(ctx, "User import %d completed: created=%d failed=%d", id, userImport.CreatedRows, userImport.FailedRows)
+	return nil
+}
+
+// FailImport marks an import as failed, it is called by the worker when retries are exhausted
+func (u *userImportUsecase) FailImport(ctx context.Context, id int64, reason string) error {
+	u.logger.Warnf(ctx, "User import %d failed: %s", id, reason)
+	return u.userImportRepo.Finish(ctx, id, model.UserImportStatusFailed, &reason)
+}
+
+// ExpireImports fails the imports that did not finish within the retention period. Their
+// tasks are gone or past their retries, so the sealed files could never be read again.
+func (u *userImportUsecase) ExpireImports(ctx context.Context) (int64, error) {
+	retention := u.config.UserImportRetention
+	if retention <= 0 {
+		retention = defaultUserImportRetention
+	}
+	return u.userImportRepo.Expire(ctx, time.Now().Add(-retention), "import did not finish in time")
+}
+
+// processBatch hashes the passwords of the valid rows and inserts them with the progress of the import
+func (u *userImportUsecase) processBatch(ctx context.Context, userImport *model.UserImport, batch []importRow) error {
+	users := make([]*model.User, len(batch))
+
+	// bcrypt dominates the import time, hash on every core
+	var g errgroup.Group
+	g.SetLimit(runtime.NumCPU())
+	for i, row := range batch {
+		if len(row.errors) > 0 {
+			continue
+		}
+		g.Go(func() error {
+			hashedPassword, err := hashPassword(u.config, row.req.Password)
+			if err != nil {
+				return err
+			}
+			users[i] = &model.User{
+				Email:    row.req.Email,
+				Name:     row.req.Name,
+				Password: hashedPassword,
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	valid := make([]*model.User, 0, len(users))
+	for _, user := range users {
+		if user != nil {
+			valid = append(valid, user)
+		}
+	}
+
+	// Work on a copy so a rolled back batch leaves the counters untouched
+	progress := *userImport
+	progress.Errors = append(model.RowErrors(nil), userImport.Errors...)
+
+	var created []*model.User
+	err := u.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		skipped, err := u.userRepo.CreateBatch(ctx, valid)
+		if err != nil {
+			return err
+		}
+
+		taken := make(map[string]bool, len(skipped))
+		for _, user := range skipped {
+			taken[user.Email] = true
+		}
+
+		for i, row := range batch {
+			rowErrors := row.errors
+			if users[i] != nil && taken[users[i].Email] {
+				rowErrors = []string{usecase.ErrEmailTaken.Error()}
+			}
+
+			if len(rowErrors) > 0 {
+				progress.FailedRows++
+				progress.Errors = append(progress.Errors, model.RowError{
+					Row:    row.row,
+					Email:  row.req.Email,
+					Errors: rowErrors,
+				})
+				continue
+			}
+
+			progress.CreatedRows++
+			created = append(created, users[i])
+		}
+		progress.ProcessedRows += len(batch)
+
+		if err := u.userImportRepo.UpdateProgress(ctx, &progress); err != nil {
+			return err
+		}
+
+		return u.auditLogRepo.CreateBatch(ctx, importAuditLogs(ctx, userImport, created))
+	})
+	if err != nil {
+		return err
+	}
+	*userImport = progress
+
+	// The accounts exist even if an email fails, users can request a resend
+	for _, user := range created {
+		if err := u.verificationSender.send(ctx, user); err != nil {
+			u.logger.Errorf(ctx, "Failed to send email verification: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// importAuditLogs builds the audit logs of the users created by an import.
+// The worker has no caller, the changes are attributed to whoever uploaded the file.
+func importAuditLogs(ctx context.Context, userImport *model.UserImport, users []*model.User) []*model.AuditLog {
+	logs := make([]*model.AuditLog, len(users))
+	for i, user := range users {
+		logs[i] = newAuditLog(ctx, model.AuditActionUserImported, model.AuditEntityUser, user.ID, auditDiff(nil, userAuditFields(user)))
+		logs[i].ActorUserID = userImport.CreatedBy
+	}
+	return logs
+}
+
+func (u *userImportUsecase) MaxBytes() int64 {
+	if u.config.UserImportMaxBytes <= 0 {
+		return defaultUserImportMaxBytes
+	}
+	return u.config.UserImportMaxBytes
+}
+
+func (u *userImportUsecase) maxRows() int {
+	if u.config.UserImportMaxRows <= 0 {
+		return defaultUserImportMaxRows
+	}
+	return u.config.UserImportMaxRows
+}
+
+// markDuplicateEmails rejects rows repeating the email of an earlier valid row of the file
+func markDuplicateEmails(rows []importRow) {
+	seen := make(map[string]int, len(rows))
+	for i := range rows {
+		if len(rows[i].errors) > 0 {
+			continue
+		}
+		if first, ok := seen[rows[i].req.Email]; ok {
+			rows[i].errors = []string{fmt.Sprintf("email duplicates row %d", first)}
+			continue
+		}
+		seen[rows[i].req.Email] = rows[i].row
+	}
+}
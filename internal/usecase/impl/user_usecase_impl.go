@@ -2,9 +2,13 @@ package impl
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"go-gin-sqlx-template/config"
+	"go-gin-sqlx-template/internal/apperror"
 	"go-gin-sqlx-template/internal/model"
 	"go-gin-sqlx-template/internal/repository"
 	"go-gin-sqlx-template/internal/usecase"
@@ -15,21 +19,30 @@ import (
 	"go-gin-sqlx-template/pkg/utils"
 
 	"github.com/hibiken/asynq"
-	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/sync/errgroup"
 )
 
+const defaultUserExportMaxRows = 100000
+
 type userUsecase struct {
-	userRepo     repository.UserRepository
-	txManager    database.Transactor
-	asynqClient  *asynq.Client
-	pubsubClient *ps.Client
-	config       config.Config
-	logger       *logger.Logger
+	userRepo           repository.UserRepository
+	sessionRepo        repository.SessionRepository
+	txManager          database.Transactor
+	asynqClient        *asynq.Client
+	pubsubClient       *ps.Client
+	verificationSender *emailVerificationSender
+	audit              *auditRecorder
+	cache              *userCache
+	config             config.Config
+	logger             *logger.Logger
 }
 
 func NewUserUsecase(
 	userRepo repository.UserRepository,
+	emailVerificationRepo repository.EmailVerificationRepository,
+	sessionRepo repository.SessionRepository,
+	auditLogRepo repository.AuditLogRepository,
+	userCacheRepo repository.UserCacheRepository,
 	txManager database.Transactor,
 	asynqClient *asynq.Client,
 	pubsubClient *ps.Client,
@@ -37,37 +50,74 @@ func NewUserUsecase(
 	log *logger.Logger,
 ) usecase.UserUsecase {
 	return &userUsecase{
-		userRepo:     userRepo,
-		txManager:    txManager,
-		asynqClient:  asynqClient,
-		pubsubClient: pubsubClient,
-		config:       cfg,
-		logger:       log,
+		userRepo:           userRepo,
+		sessionRepo:        sessionRepo,
+		txManager:          txManager,
+		asynqClient:        asynqClient,
+		pubsubClient:       pubsubClient,
+		verificationSender: newEmailVerificationSender(emailVerificationRepo, asynqClient, cfg, log),
+		audit:              newAuditRecorder(auditLogRepo),
+		cache:              newUserCache(userCacheRepo, log),
+		config:             cfg,
+		logger:             log,
 	}
 }
 
 func (u *userUsecase) CreateUser(ctx context.Context, req model.CreateUserRequest) (*model.UserResponse, error) {
-	// Check if email already exists
-	existingUser, _ := u.userRepo.GetByEmail(ctx, req.Email)
-	if existingUser != nil {
-		return nil, fmt.Errorf("email already exists")
+	user, err := u.createUser(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
+	u.notifyUserCreated(ctx, user)
+
+	response := user.ToResponse()
+	return &response, nil
+}
+
+// createUser inserts the user, the notifications are left to the caller so they
+// can be sent after the transaction commits
+func (u *userUsecase) createUser(ctx context.Context, req model.CreateUserRequest) (*model.User, error) {
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(u.config, req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, err
 	}
 
 	user := &model.User{
 		Email:    req.Email,
 		Name:     req.Name,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
 	}
 
-	err = u.userRepo.Create(ctx, user)
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.userRepo.Create(txCtx, user); err != nil {
+			return err
+		}
+		return u.audit.recordUser(txCtx, model.AuditActionUserCreated, user.ID, nil, userAuditFields(user))
+	})
 	if err != nil {
-		return nil, err
+		return nil, emailTakenError(err)
+	}
+
+	return user, nil
+}
+
+// emailTakenError returns usecase.ErrEmailTaken when err is the unique index of active
+// emails rejecting a write, err otherwise. The index decides instead of a lookup before
+// the write, which concurrent requests could both pass.
+func emailTakenError(err error) error {
+	if repository.IsConstraintViolation(err, repository.ErrUniqueViolation, repository.ConstraintUserEmail) {
+		return usecase.ErrEmailTaken
+	}
+	return err
+}
+
+// notifyUserCreated sends the verification email and announces the new user
+func (u *userUsecase) notifyUserCreated(ctx context.Context, user *model.User) {
+	// The account exists even if the email fails, the user can request a resend
+	if err := u.verificationSender.send(ctx, user); err != nil {
+		u.logger.Errorf(ctx, "Failed to send email verification: %v", err)
 	}
 
 	// NOTE:
@@ -96,9 +146,6 @@ func (u *userUsecase) CreateUser(ctx context.Context, req model.CreateUserReques
 			u.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
 		}
 	}
-
-	response := user.ToResponse()
-	return &response, nil
 }
 
 func (u *userUsecase) GetUserByID(ctx context.Context, id int64) (*model.UserResponse, error) {
@@ -143,30 +190,141 @@ func (u *userUsecase) GetAllUsers(ctx context.Context, pagination utils.Paginati
 	return responses, total, nil
 }
 
-func (u *userUsecase) UpdateUser(ctx context.Context, id int64, req model.UpdateUserRequest) (*model.UserResponse, error) {
+// ExportUsers streams the users from one transaction, so the export is a consistent
+// snapshot even while users are being written
+func (u *userUsecase) ExportUsers(ctx context.Context, filters utils.FilterParams, sort []utils.SortParams, fn func(user model.UserResponse) error) error {
+	limit := u.config.UserExportMaxRows
+	if limit <= 0 {
+		limit = defaultUserExportMaxRows
+	}
+
+	return u.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		return u.userRepo.Export(ctx, filters, sort, limit, func(user *model.User) error {
+			return fn(user.ToResponse())
+		})
+	})
+}
+
+// UpdateUser replaces the updatable fields of the user (PUT semantics)
+func (u *userUsecase) UpdateUser(ctx context.Context, id int64, req model.UpdateUserRequest, ifMatch utils.IfMatch) (*model.UserResponse, error) {
 	user, err := u.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if new email already exists (if email is being updated)
-	if req.Email != "" && req.Email != user.Email {
-		existingUser, _ := u.userRepo.GetByEmail(ctx, req.Email)
-		if existingUser != nil {
-			return nil, fmt.Errorf("email already exists")
-		}
-		user.Email = req.Email
+	if err := u.checkPrecondition(user, ifMatch); err != nil {
+		return nil, err
+	}
+
+	return u.applyUserChanges(ctx, user, &req.Email, &req.Name)
+}
+
+// PatchUser applies a JSON Merge Patch to the user, absent fields are left unchanged
+func (u *userUsecase) PatchUser(ctx context.Context, id int64, req model.PatchUserRequest, ifMatch utils.IfMatch) (*model.UserResponse, error) {
+	email, name, err := patchValues(req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkPrecondition(user, ifMatch); err != nil {
+		return nil, err
 	}
 
-	if req.Name != "" {
-		user.Name = req.Name
+	return u.applyUserChanges(ctx, user, email, name)
+}
+
+// patchValues returns the fields set by the merge patch, nil for absent ones
+func patchValues(req model.PatchUserRequest) (email, name *string, err error) {
+	// Email and name are required, so they cannot be removed with null
+	if req.Email.Null {
+		return nil, nil, apperror.Detail(usecase.ErrInvalidPatch, "email cannot be null")
+	}
+	if req.Name.Null {
+		return nil, nil, apperror.Detail(usecase.ErrInvalidPatch, "name cannot be null")
 	}
 
-	err = u.userRepo.Update(ctx, user)
+	if req.Email.HasValue() {
+		email = &req.Email.Value
+	}
+	if req.Name.HasValue() {
+		name = &req.Name.Value
+	}
+	return email, name, nil
+}
+
+// applyUserChanges sets the non-nil fields on the user and writes only the columns that changed
+func (u *userUsecase) applyUserChanges(ctx context.Context, user *model.User, email, name *string) (*model.UserResponse, error) {
+	columns, err := u.updateUser(ctx, user, email, name)
 	if err != nil {
 		return nil, err
 	}
 
+	u.notifyUserUpdated(ctx, user, columns)
+
+	response := user.ToResponse()
+	return &response, nil
+}
+
+// updateUser writes the changed fields and returns the columns written,
+// none if nothing changed
+func (u *userUsecase) updateUser(ctx context.Context, user *model.User, email, name *string) ([]string, error) {
+	var columns []string
+	before := userAuditFields(user)
+
+	// A taken email is rejected by the unique index when writing
+	if email != nil && *email != user.Email {
+		user.Email = *email
+		user.EmailVerifiedAt = nil
+		columns = append(columns, "email", "email_verified_at")
+	}
+
+	if name != nil && *name != user.Name {
+		user.Name = *name
+		columns = append(columns, "name")
+	}
+
+	// Nothing changed, skip the write
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.userRepo.Update(txCtx, user, columns); err != nil {
+			return err
+		}
+		return u.audit.recordUser(txCtx, model.AuditActionUserUpdated, user.ID, before, userAuditFields(user))
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, usecase.ErrPreconditionFailed
+		}
+		return nil, emailTakenError(err)
+	}
+
+	return columns, nil
+}
+
+// notifyUserUpdated asks to verify a changed email again and announces the update
+func (u *userUsecase) notifyUserUpdated(ctx context.Context, user *model.User, columns []string) {
+	// Nothing changed, nothing to announce
+	if len(columns) == 0 {
+		return
+	}
+
+	u.cache.invalidate(ctx, user.ID)
+
+	// A changed address has to be verified again
+	if slices.Contains(columns, "email") {
+		if err := u.verificationSender.send(ctx, user); err != nil {
+			u.logger.Errorf(ctx, "Failed to send email verification: %v", err)
+		}
+	}
+
 	// Send Telegram message with asynq task
 	taskPayload := fmt.Sprintf("User updated: %s (%s)", user.Name, user.Email)
 	task, _ := worker.NewTelegramMessageTask(ctx, u.config.TelegramChatID, taskPayload)
@@ -179,13 +337,118 @@ func (u *userUsecase) UpdateUser(ctx context.Context, id int64, req model.Update
 			u.logger.Infof(ctx, "Enqueued task: id=%s queue=%s", info.ID, info.Queue)
 		}
 	}
+}
+
+func (u *userUsecase) DeleteUser(ctx context.Context, id int64, ifMatch utils.IfMatch) error {
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.deleteUser(ctx, user, ifMatch); err != nil {
+		return err
+	}
+
+	u.notifyUserDeleted(ctx, user)
+	return nil
+}
+
+// notifyUserDeleted signs the deleted user out of every session and drops its cached response.
+// Sessions live in Redis, they are only revoked once the delete is committed so a rolled
+// back delete leaves the user signed in.
+func (u *userUsecase) notifyUserDeleted(ctx context.Context, user *model.User) {
+	if err := u.sessionRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		u.logger.Errorf(ctx, "Failed to revoke sessions of deleted user %d: %v", user.ID, err)
+	}
+	u.cache.invalidate(ctx, user.ID)
+}
+
+// deleteUser soft deletes the user if it is still at the version it was read at.
+// Call notifyUserDeleted once the delete is committed.
+func (u *userUsecase) deleteUser(ctx context.Context, user *model.User, ifMatch utils.IfMatch) error {
+	if err := u.checkPrecondition(user, ifMatch); err != nil {
+		return err
+	}
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.userRepo.Delete(txCtx, user.ID, user.Version); err != nil {
+			return err
+		}
+
+		deleted := *user
+		deletedAt := time.Now()
+		deleted.DeletedAt = &deletedAt
+		return u.audit.recordUser(txCtx, model.AuditActionUserDeleted, user.ID, userAuditFields(user), userAuditFields(&deleted))
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return usecase.ErrPreconditionFailed
+	}
+	return err
+}
+
+// checkPrecondition enforces the If-Match header against the version the user was read at.
+// The conditional write in the repository catches changes made after this check.
+func (u *userUsecase) checkPrecondition(user *model.User, ifMatch utils.IfMatch) error {
+	if !ifMatch.Present && u.config.RequireIfMatch {
+		return usecase.ErrPreconditionRequired
+	}
+	if !ifMatch.Matches(user.Version) {
+		return usecase.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// RestoreUser undoes a soft delete. It fails if another active user took the email in the meantime.
+func (u *userUsecase) RestoreUser(ctx context.Context, id int64) (*model.UserResponse, error) {
+	var user *model.User
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		deleted, err := u.userRepo.GetDeletedByID(txCtx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return usecase.ErrUserNotDeleted
+			}
+			return err
+		}
+
+		if err := u.userRepo.Restore(txCtx, id); err != nil {
+			return emailTakenError(err)
+		}
+
+		user, err = u.userRepo.GetByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		return u.audit.recordUser(txCtx, model.AuditActionUserRestored, id, userAuditFields(deleted), userAuditFields(user))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.cache.invalidate(ctx, id)
 
 	response := user.ToResponse()
 	return &response, nil
 }
 
-func (u *userUsecase) DeleteUser(ctx context.Context, id int64) error {
-	return u.userRepo.Delete(ctx, id)
+func (u *userUsecase) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		ids, err := u.userRepo.PurgeDeleted(txCtx, deletedBefore)
+		if err != nil {
+			return err
+		}
+		purged = len(ids)
+
+		return u.audit.recordUsers(txCtx, model.AuditActionUserPurged, ids)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
 }
 
 // CreateUserWithTransaction is an example method demonstrating transaction usage
@@ -199,41 +462,37 @@ func (u *userUsecase) CreateUserWithTransaction(ctx context.Context, req model.C
 
 	// Execute all operations within a transaction
 	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
-		// Check if email already exists
-		existingUser, _ := u.userRepo.GetByEmail(txCtx, req.Email)
-		if existingUser != nil {
-			return fmt.Errorf("email already exists")
-		}
-
 		// Hash password
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		hashedPassword, err := hashPassword(u.config, req.Password)
 		if err != nil {
-			return fmt.Errorf("failed to hash password: %w", err)
+			return err
 		}
 
 		user = &model.User{
 			Email:    req.Email,
 			Name:     req.Name,
-			Password: string(hashedPassword),
+			Password: hashedPassword,
 		}
 
-		// Create user - this will use the transaction from txCtx
+		// Create user - this will use the transaction from txCtx.
+		// A taken email fails here and rolls back the transaction.
 		if err := u.userRepo.Create(txCtx, user); err != nil {
-			return err // Will trigger rollback
+			return emailTakenError(err) // Will trigger rollback
+		}
+
+		// Other repositories are called with the same txCtx, a failing
+		// audit log rolls back the user creation as well
+		if err := u.audit.recordUser(txCtx, model.AuditActionUserCreated, user.ID, nil, userAuditFields(user)); err != nil {
+			return err // Will rollback all previous operations
 		}
 
-		// If you had other repositories (e.g., ProfileRepository, AuditLogRepository),
+		// If you had other repositories (e.g., ProfileRepository),
 		// you would call them here with the same txCtx:
 		//
 		// profile := &model.Profile{UserID: user.ID, ...}
 		// if err := u.profileRepo.Create(txCtx, profile); err != nil {
 		//     return err // Will rollback both user and profile creation
 		// }
-		//
-		// auditLog := &model.AuditLog{Action: "user_created", UserID: user.ID}
-		// if err := u.auditLogRepo.Create(txCtx, auditLog); err != nil {
-		//     return err // Will rollback all previous operations
-		// }
 
 		// If we reach here, all operations succeeded and will be committed
 		return nil
@@ -247,6 +506,10 @@ func (u *userUsecase) CreateUserWithTransaction(ctx context.Context, req model.C
 	// These are outside the transaction because they're not critical
 	// and we don't want to rollback the user creation if notification fails
 
+	if err := u.verificationSender.send(ctx, user); err != nil {
+		u.logger.Errorf(ctx, "Failed to send email verification: %v", err)
+	}
+
 	// Send PubSub message
 	message := fmt.Sprintf("New user created: %s (%s)", user.Name, user.Email)
 	if id, err := u.pubsubClient.Publish(ctx, u.config.PubSubTopicUserCreated, []byte(message), nil); err != nil {
@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type MFAUsecase interface {
+	Enroll(ctx context.Context, userID int64) (*model.EnrollMFAResponse, error)
+	Confirm(ctx context.Context, userID int64, req model.ConfirmMFARequest) (*model.ConfirmMFAResponse, error)
+	Reset(ctx context.Context, userID int64) error
+}
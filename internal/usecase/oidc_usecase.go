@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type OIDCUsecase interface {
+	ListProviders(ctx context.Context) []model.OIDCProviderResponse
+	// AuthorizationURL starts a login and returns the provider URL to redirect the user to
+	AuthorizationURL(ctx context.Context, provider string) (string, error)
+	// Callback completes the login with the code and state the provider redirected back with
+	Callback(ctx context.Context, provider, code, state string, client model.ClientInfo) (*model.LoginResponse, error)
+}
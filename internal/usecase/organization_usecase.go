@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/pkg/auth"
+	"go-gin-sqlx-template/pkg/utils"
+)
+
+type OrganizationUsecase interface {
+	CreateOrganization(ctx context.Context, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error)
+	GetOrganization(ctx context.Context, id int64) (*model.OrganizationResponse, error)
+	GetAllOrganizations(ctx context.Context, pagination utils.PaginationParams, sort []utils.SortParams) ([]model.OrganizationResponse, int64, error)
+	// GetUserOrganizations returns the organizations the user is a member of, in the order they joined
+	GetUserOrganizations(ctx context.Context, userID int64) ([]model.OrganizationResponse, error)
+	AddMember(ctx context.Context, organizationID, userID int64) error
+	RemoveMember(ctx context.Context, organizationID, userID int64) error
+	// ResolveOrganization returns the organization a request of the caller is scoped to: the requested one,
+	// or the one of the token when requestedID is 0. It returns 0 for callers holding organizations:manage
+	// that request none, they are not scoped.
+	ResolveOrganization(ctx context.Context, claims *auth.Claims, requestedID int64) (int64, error)
+}
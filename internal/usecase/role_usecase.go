@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type RoleUsecase interface {
+	GetAllRoles(ctx context.Context) ([]model.RoleResponse, error)
+	GetUserRoles(ctx context.Context, userID int64) ([]model.RoleResponse, error)
+	AssignRole(ctx context.Context, userID int64, roleName string) error
+	RemoveRole(ctx context.Context, userID int64, roleName string) error
+}
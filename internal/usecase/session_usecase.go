@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+	"go-gin-sqlx-template/pkg/auth"
+)
+
+type SessionUsecase interface {
+	ListSessions(ctx context.Context, userID int64, currentSessionID string) ([]model.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID int64) error
+	// ValidateSession returns ErrSessionRevoked if the session of the access token has been revoked
+	ValidateSession(ctx context.Context, claims *auth.Claims) error
+}
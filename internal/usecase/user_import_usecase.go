@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"go-gin-sqlx-template/internal/model"
+)
+
+type UserImportUsecase interface {
+	// CreateImport checks the file and enqueues it for the worker
+	CreateImport(ctx context.Context, filename, format string, data []byte) (*model.UserImportResponse, error)
+	// MaxBytes is the largest file CreateImport accepts
+	MaxBytes() int64
+	GetImport(ctx context.Context, id int64) (*model.UserImportResponse, error)
+	// ProcessImport creates the users of an import, it is called by the worker
+	// with the key of the stored file
+	ProcessImport(ctx context.Context, id int64, key []byte) error
+	// FailImport marks an import as failed, it is called by the worker when retries are exhausted
+	FailImport(ctx context.Context, id int64, reason string) error
+	// ExpireImports fails the imports left unfinished longer than USER_IMPORT_RETENTION
+	// and drops their files, it is called periodically by the worker
+	ExpireImports(ctx context.Context) (int64, error)
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"time"
+
 	"go-gin-sqlx-template/internal/model"
 	"go-gin-sqlx-template/pkg/utils"
 )
@@ -10,6 +12,23 @@ type UserUsecase interface {
 	CreateUser(ctx context.Context, req model.CreateUserRequest) (*model.UserResponse, error)
 	GetUserByID(ctx context.Context, id int64) (*model.UserResponse, error)
 	GetAllUsers(ctx context.Context, pagination utils.PaginationParams, filters utils.FilterParams, sort []utils.SortParams) ([]model.UserResponse, int64, error)
-	UpdateUser(ctx context.Context, id int64, req model.UpdateUserRequest) (*model.UserResponse, error)
-	DeleteUser(ctx context.Context, id int64) error
+	// ExportUsers calls fn for each user matching the filters, up to the configured row cap
+	ExportUsers(ctx context.Context, filters utils.FilterParams, sort []utils.SortParams, fn func(user model.UserResponse) error) error
+	UpdateUser(ctx context.Context, id int64, req model.UpdateUserRequest, ifMatch utils.IfMatch) (*model.UserResponse, error)
+	PatchUser(ctx context.Context, id int64, req model.PatchUserRequest, ifMatch utils.IfMatch) (*model.UserResponse, error)
+	DeleteUser(ctx context.Context, id int64, ifMatch utils.IfMatch) error
+	RestoreUser(ctx context.Context, id int64) (*model.UserResponse, error)
+	// PurgeDeletedUsers permanently removes users soft deleted before the given time
+	// and returns how many were removed
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error)
+	// BatchUsers applies the operations in order and returns one result per operation.
+	// In atomic mode the first failure rolls back all of them.
+	BatchUsers(ctx context.Context, req model.BatchUserRequest) []BatchUserResult
+}
+
+// BatchUserResult is the outcome of one batch operation
+type BatchUserResult struct {
+	// User is the created or updated user
+	User *model.UserResponse
+	Err  error
 }
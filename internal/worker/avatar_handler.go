@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// AvatarTaskHandler handles avatar thumbnail tasks
+type AvatarTaskHandler struct {
+	logger        *logger.Logger
+	avatarUsecase usecase.AvatarUsecase
+}
+
+// NewAvatarTaskHandler creates a new AvatarTaskHandler
+func NewAvatarTaskHandler(logger *logger.Logger, avatarUsecase usecase.AvatarUsecase) *AvatarTaskHandler {
+	return &AvatarTaskHandler{
+		logger:        logger,
+		avatarUsecase: avatarUsecase,
+	}
+}
+
+// HandleAvatarThumbnailsTask resizes an uploaded avatar into its thumbnails
+func (h *AvatarTaskHandler) HandleAvatarThumbnailsTask(ctx context.Context, t *asynq.Task) error {
+	var p AvatarThumbnailsPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		h.logger.Errorf(ctx, "json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Extract trace context and start span
+	if p.TraceContext != nil {
+		carrier := propagation.MapCarrier(p.TraceContext)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	}
+
+	tracer := otel.Tracer(t.ResultWriter().TaskID())
+	ctx, span := tracer.Start(ctx, "HandleAvatarThumbnailsTask")
+	defer span.End()
+
+	h.logger.Infof(ctx, "Generating avatar thumbnails for user %d", p.UserID)
+	if err := h.avatarUsecase.GenerateThumbnails(ctx, p.UserID, p.AvatarKey); err != nil {
+		h.logger.Errorf(ctx, "Failed to generate avatar thumbnails: %v", err)
+		span.RecordError(err)
+		return fmt.Errorf("failed to generate avatar thumbnails: %w", err)
+	}
+
+	return nil
+}
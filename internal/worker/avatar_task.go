@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// AvatarThumbnailsPayload represents the payload for generating the thumbnails of an avatar
+type AvatarThumbnailsPayload struct {
+	UserID       int64             `json:"user_id"`
+	AvatarKey    string            `json:"avatar_key"`
+	TraceContext map[string]string `json:"trace_context"`
+}
+
+// NewAvatarThumbnailsTask creates a new task for generating the thumbnails of an uploaded avatar
+func NewAvatarThumbnailsTask(ctx context.Context, userID int64, avatarKey string) (*asynq.Task, error) {
+	// Inject trace context
+	traceContext := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(traceContext))
+
+	payload := AvatarThumbnailsPayload{
+		UserID:       userID,
+		AvatarKey:    avatarKey,
+		TraceContext: traceContext,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeAvatarThumbnails, payloadBytes, asynq.Queue("default")), nil
+}
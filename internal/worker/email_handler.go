@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-gin-sqlx-template/internal/integration/mail"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// EmailTaskHandler handles email-related tasks
+type EmailTaskHandler struct {
+	logger      *logger.Logger
+	mailService *mail.MailService
+}
+
+// NewEmailTaskHandler creates a new EmailTaskHandler
+func NewEmailTaskHandler(logger *logger.Logger, mailService *mail.MailService) *EmailTaskHandler {
+	return &EmailTaskHandler{
+		logger:      logger,
+		mailService: mailService,
+	}
+}
+
+// HandlePasswordResetEmailTask processes password reset email tasks
+func (h *EmailTaskHandler) HandlePasswordResetEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p PasswordResetEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		h.logger.Errorf(ctx, "json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Extract trace context and start span
+	if p.TraceContext != nil {
+		carrier := propagation.MapCarrier(p.TraceContext)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	}
+
+	tracer := otel.Tracer(t.ResultWriter().TaskID())
+	ctx, span := tracer.Start(ctx, "HandlePasswordResetEmailTask")
+	defer span.End()
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires at %s and can only be used once.\nIf you did not request this, you can ignore this email.\n",
+		p.Name, p.ResetURL, p.ExpiresAt.Format("2006-01-02 15:04 MST"),
+	)
+
+	h.logger.Info(ctx, "Sending password reset email")
+	err := h.mailService.SendMail(ctx, p.Email, "Reset your password", body)
+	if err != nil {
+		h.logger.Errorf(ctx, "Failed to send password reset email: %v", err)
+		span.RecordError(err)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	h.logger.Info(ctx, "Password reset email sent successfully")
+	return nil
+}
+
+// HandleEmailVerificationTask processes email verification tasks
+func (h *EmailTaskHandler) HandleEmailVerificationTask(ctx context.Context, t *asynq.Task) error {
+	var p EmailVerificationPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		h.logger.Errorf(ctx, "json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Extract trace context and start span
+	if p.TraceContext != nil {
+		carrier := propagation.MapCarrier(p.TraceContext)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	}
+
+	tracer := otel.Tracer(t.ResultWriter().TaskID())
+	ctx, span := tracer.Start(ctx, "HandleEmailVerificationTask")
+	defer span.End()
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires at %s.\nIf you did not create an account, you can ignore this email.\n",
+		p.Name, p.VerifyURL, p.ExpiresAt.Format("2006-01-02 15:04 MST"),
+	)
+
+	h.logger.Info(ctx, "Sending email verification")
+	err := h.mailService.SendMail(ctx, p.Email, "Verify your email address", body)
+	if err != nil {
+		h.logger.Errorf(ctx, "Failed to send email verification: %v", err)
+		span.RecordError(err)
+		return fmt.Errorf("failed to send email verification: %w", err)
+	}
+
+	h.logger.Info(ctx, "Email verification sent successfully")
+	return nil
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// PasswordResetEmailPayload represents the payload for sending password reset emails
+type PasswordResetEmailPayload struct {
+	Email        string            `json:"email"`
+	Name         string            `json:"name"`
+	ResetURL     string            `json:"reset_url"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+	TraceContext map[string]string `json:"trace_context"`
+}
+
+// NewPasswordResetEmailTask creates a new task for sending a password reset email
+func NewPasswordResetEmailTask(ctx context.Context, email, name, resetURL string, expiresAt time.Time) (*asynq.Task, error) {
+	// Inject trace context
+	traceContext := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(traceContext))
+
+	payload := PasswordResetEmailPayload{
+		Email:        email,
+		Name:         name,
+		ResetURL:     resetURL,
+		ExpiresAt:    expiresAt,
+		TraceContext: traceContext,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	// The link expires, so retrying for longer than its lifetime is pointless
+	return asynq.NewTask(TypePasswordResetEmail, payloadBytes, asynq.Queue("critical"), asynq.Deadline(expiresAt)), nil
+}
+
+// EmailVerificationPayload represents the payload for sending email verification links
+type EmailVerificationPayload struct {
+	Email        string            `json:"email"`
+	Name         string            `json:"name"`
+	VerifyURL    string            `json:"verify_url"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+	TraceContext map[string]string `json:"trace_context"`
+}
+
+// NewEmailVerificationTask creates a new task for sending an email verification link
+func NewEmailVerificationTask(ctx context.Context, email, name, verifyURL string, expiresAt time.Time) (*asynq.Task, error) {
+	// Inject trace context
+	traceContext := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(traceContext))
+
+	payload := EmailVerificationPayload{
+		Email:        email,
+		Name:         name,
+		VerifyURL:    verifyURL,
+		ExpiresAt:    expiresAt,
+		TraceContext: traceContext,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeEmailVerification, payloadBytes, asynq.Queue("critical"), asynq.Deadline(expiresAt)), nil
+}
@@ -3,5 +3,11 @@ package worker
 // Task Types
 // This file serves as a central registry for all worker task type constants
 const (
-	TypeTelegramMessage = "telegram:send_message"
+	TypeTelegramMessage    = "telegram:send_message"
+	TypePasswordResetEmail = "email:password_reset"
+	TypeEmailVerification  = "email:verification"
+	TypePurgeDeletedUsers  = "user:purge_deleted"
+	TypeUserImport         = "user:import"
+	TypeExpireUserImports  = "user:import_expire"
+	TypeAvatarThumbnails   = "user:avatar_thumbnails"
 )
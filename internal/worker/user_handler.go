@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+)
+
+const defaultDeletedUserRetention = 30 * 24 * time.Hour
+
+// UserTaskHandler handles user maintenance tasks
+type UserTaskHandler struct {
+	logger      *logger.Logger
+	userUsecase usecase.UserUsecase
+	retention   time.Duration
+}
+
+// NewUserTaskHandler creates a new UserTaskHandler
+func NewUserTaskHandler(logger *logger.Logger, userUsecase usecase.UserUsecase, retention time.Duration) *UserTaskHandler {
+	if retention <= 0 {
+		retention = defaultDeletedUserRetention
+	}
+	return &UserTaskHandler{
+		logger:      logger,
+		userUsecase: userUsecase,
+		retention:   retention,
+	}
+}
+
+// HandlePurgeDeletedUsersTask permanently removes users deleted before the retention period.
+// The task runs without claims, so the audit log records the system as the actor.
+func (h *UserTaskHandler) HandlePurgeDeletedUsersTask(ctx context.Context, t *asynq.Task) error {
+	tracer := otel.Tracer(t.ResultWriter().TaskID())
+	ctx, span := tracer.Start(ctx, "HandlePurgeDeletedUsersTask")
+	defer span.End()
+
+	purged, err := h.userUsecase.PurgeDeletedUsers(ctx, time.Now().Add(-h.retention))
+	if err != nil {
+		h.logger.Errorf(ctx, "Failed to purge deleted users: %v", err)
+		span.RecordError(err)
+		return fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+
+	h.logger.Infof(ctx, "Purged %d deleted users", purged)
+	return nil
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-gin-sqlx-template/internal/usecase"
+	"go-gin-sqlx-template/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// UserImportTaskHandler handles bulk user import tasks
+type UserImportTaskHandler struct {
+	logger            *logger.Logger
+	userImportUsecase usecase.UserImportUsecase
+}
+
+// NewUserImportTaskHandler creates a new UserImportTaskHandler
+func NewUserImportTaskHandler(logger *logger.Logger, userImportUsecase usecase.UserImportUsecase) *UserImportTaskHandler {
+	return &UserImportTaskHandler{
+		logger:            logger,
+		userImportUsecase: userImportUsecase,
+	}
+}
+
+// HandleUserImportTask creates the users of an uploaded import file.
+// Failed attempts resume after the last committed batch, the import is marked
+// failed once the retries are exhausted.
+func (h *UserImportTaskHandler) HandleUserImportTask(ctx context.Context, t *asynq.Task) error {
+	var p UserImportPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		h.logger.Errorf(ctx, "json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Extract trace context and start span
+	if p.TraceContext != nil {
+		carrier := propagation.MapCarrier(p.TraceContext)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	}
+
+	tracer := otel.Tracer(t.ResultWriter().TaskID())
+	ctx, span := tracer.Start(ctx, "HandleUserImportTask")
+	defer span.End()
+
+	h.logger.Infof(ctx, "Processing user import %d", p.ImportID)
+	err := h.userImportUsecase.ProcessImport(ctx, p.ImportID, p.Key)
+	if err != nil {
+		h.logger.Errorf(ctx, "Failed to process user import: %v", err)
+		span.RecordError(err)
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retried >= maxRetry {
+			if failErr := h.userImportUsecase.FailImport(context.WithoutCancel(ctx), p.ImportID, "import could not be completed"); failErr != nil {
+				h.logger.Errorf(ctx, "Failed to mark user import as failed: %v", failErr)
+			}
+		}
+		return fmt.Errorf("failed to process user import: %w", err)
+	}
+
+	return nil
+}
+
+// HandleExpireUserImportsTask fails the imports that never finished within the retention period
+func (h *UserImportTaskHandler) HandleExpireUserImportsTask(ctx context.Context, t *asynq.Task) error {
+	expired, err := h.userImportUsecase.ExpireImports(ctx)
+	if err != nil {
+		h.logger.Errorf(ctx, "Failed to expire user imports: %v", err)
+		return fmt.Errorf("failed to expire user imports: %w", err)
+	}
+
+	if expired > 0 {
+		h.logger.Infof(ctx, "Expired %d unfinished user imports", expired)
+	}
+	return nil
+}
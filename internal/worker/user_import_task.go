@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// UserImportPayload represents the payload for processing a bulk user import
+type UserImportPayload struct {
+	ImportID int64 `json:"import_id"`
+	// Key decrypts the file stored with the import, it is only kept in the task
+	Key          []byte            `json:"key"`
+	TraceContext map[string]string `json:"trace_context"`
+}
+
+// userImportMaxRetry keeps the retries of an import well within USER_IMPORT_RETENTION
+const userImportMaxRetry = 5
+
+// NewUserImportTask creates a new task for processing a bulk user import.
+// The task ID is derived from the import so it is never enqueued twice.
+func NewUserImportTask(ctx context.Context, importID int64, key []byte) (*asynq.Task, error) {
+	// Inject trace context
+	traceContext := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(traceContext))
+
+	payload := UserImportPayload{
+		ImportID:     importID,
+		Key:          key,
+		TraceContext: traceContext,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(
+		TypeUserImport,
+		payloadBytes,
+		asynq.Queue("low"),
+		asynq.TaskID(fmt.Sprintf("user_import:%d", importID)),
+		asynq.Timeout(time.Hour),
+		asynq.MaxRetry(userImportMaxRetry),
+	), nil
+}
+
+// NewExpireUserImportsTask creates the periodic task that fails the imports left unfinished
+// longer than the retention period and drops their files.
+// Unique only drops duplicates while a run is pending, a single scheduler
+// (see WORKER_SCHEDULER_DISABLED) is what keeps replicas from running it twice.
+func NewExpireUserImportsTask() *asynq.Task {
+	return asynq.NewTask(TypeExpireUserImports, nil, asynq.Queue("low"), asynq.Unique(30*time.Minute))
+}
@@ -0,0 +1,15 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// NewPurgeDeletedUsersTask creates the periodic task that permanently removes
+// users soft deleted longer ago than the retention period.
+// Unique only drops duplicates while a run is pending, a single scheduler
+// (see WORKER_SCHEDULER_DISABLED) is what keeps replicas from running it twice.
+func NewPurgeDeletedUsersTask() *asynq.Task {
+	return asynq.NewTask(TypePurgeDeletedUsers, nil, asynq.Queue("low"), asynq.Unique(time.Hour))
+}
@@ -0,0 +1,9 @@
+DELETE FROM permissions WHERE name = 'users:restore';
+
+-- Soft-deleted rows would violate the restored unique constraint
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_email_active;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
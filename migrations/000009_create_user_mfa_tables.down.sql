@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'mfa:reset';
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
@@ -0,0 +1,2 @@
+DELETE FROM permissions WHERE name = 'users:import';
+DROP TABLE IF EXISTS user_imports;
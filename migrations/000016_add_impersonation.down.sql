@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'users:impersonate';
+DROP INDEX IF EXISTS idx_audit_logs_impersonator_user_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS impersonator_user_id;
@@ -0,0 +1,13 @@
+-- The admin behind an impersonation token, actor_user_id holds the impersonated user
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS impersonator_user_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_impersonator_user_id ON audit_logs(impersonator_user_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:impersonate', 'Act as another user with a short-lived impersonation token')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name = 'users:impersonate'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name = 'organizations:manage';
+ALTER TABLE user_imports DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+-- Existing users keep working as members of a default organization
+INSERT INTO organizations (name)
+SELECT 'Default' WHERE NOT EXISTS (SELECT 1 FROM organizations);
+
+INSERT INTO organization_members (organization_id, user_id)
+SELECT (SELECT MIN(id) FROM organizations), id FROM users
+ON CONFLICT DO NOTHING;
+
+-- Imports create their users in the organization they were uploaded to
+ALTER TABLE user_imports ADD COLUMN IF NOT EXISTS organization_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE;
+
+INSERT INTO permissions (name, description) VALUES
+    ('organizations:manage', 'Create organizations, manage their members and access every organization')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name = 'organizations:manage'
+ON CONFLICT DO NOTHING;
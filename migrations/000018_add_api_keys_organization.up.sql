@@ -0,0 +1,9 @@
+-- API keys act within the organization they were created in
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS organization_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys(organization_id);
+
+-- Existing keys keep working in the default organization, like the existing users.
+-- Keys that manage organizations stay unscoped and may select any organization.
+UPDATE api_keys SET organization_id = (SELECT MIN(id) FROM organizations)
+WHERE organization_id IS NULL AND NOT ('organizations:manage' = ANY(scopes));
@@ -0,0 +1,9 @@
+-- The organization the change was made in, nil for changes made outside of one such as logins
+-- and background tasks. No foreign key, the trail has to outlive deleted organizations.
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS organization_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_organization_id ON audit_logs(organization_id);
+
+-- Entries written before organizations existed belong to the default organization, like the existing users
+UPDATE audit_logs SET organization_id = (SELECT MIN(id) FROM organizations)
+WHERE organization_id IS NULL;
@@ -0,0 +1,6 @@
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name = 'organizations:manage'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM roles WHERE name = 'platform_admin';
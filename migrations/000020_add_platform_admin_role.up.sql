@@ -0,0 +1,20 @@
+-- Roles are assigned within an organization, so the admin role must not reach the other ones.
+-- Managing organizations moves to a platform_admin role holding every permission.
+INSERT INTO roles (name, description) VALUES
+    ('platform_admin', 'Full access to every resource of every organization')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'platform_admin'
+ON CONFLICT DO NOTHING;
+
+-- Existing admins predate organizations and keep managing them
+INSERT INTO user_roles (user_id, role_id)
+SELECT ur.user_id, (SELECT id FROM roles WHERE name = 'platform_admin')
+FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM role_permissions
+WHERE role_id = (SELECT id FROM roles WHERE name = 'admin')
+  AND permission_id = (SELECT id FROM permissions WHERE name = 'organizations:manage');
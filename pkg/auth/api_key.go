@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// apiKeyTag makes keys recognizable in logs and secret scanners
+	apiKeyTag = "sk_"
+
+	// apiKeyPrefixBytes is the amount of random bytes in the public lookup prefix
+	apiKeyPrefixBytes = 6
+)
+
+// GenerateAPIKey returns a new API key in the form sk_<prefix>.<secret>
+// together with its public prefix. The prefix is stored in plain text for
+// lookup, the full key is only ever stored hashed.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	b := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key prefix: %w", err)
+	}
+	prefix = apiKeyTag + hex.EncodeToString(b)
+
+	secret, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	return prefix + "." + secret, prefix, nil
+}
+
+// APIKeyPrefix extracts the public prefix from an API key
+func APIKeyPrefix(key string) (string, bool) {
+	prefix, secret, found := strings.Cut(key, ".")
+	if !found || !strings.HasPrefix(prefix, apiKeyTag) || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
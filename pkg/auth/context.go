@@ -0,0 +1,31 @@
+package auth
+
+import "context"
+
+// contextKey is a custom type for context keys to avoid collisions
+type contextKey string
+
+const (
+	// claimsKey is the context key for storing authenticated claims
+	claimsKey contextKey = "auth_claims"
+)
+
+// WithClaims returns a copy of ctx carrying the authenticated claims
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// ClaimsFromContext retrieves the authenticated claims from context if they exist
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// UserIDFromContext retrieves the authenticated user ID from context if it exists
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	return claims.UserID, true
+}
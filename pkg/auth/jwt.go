@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"go-gin-sqlx-template/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	defaultAccessTokenTTL = 15 * time.Minute
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Claims represents the JWT claims issued by this service
+type Claims struct {
+	UserID      int64    `json:"user_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// APIKeyID is set instead of UserID when the caller authenticated with an API key
+	APIKeyID int64 `json:"api_key_id,omitempty"`
+	// SessionID identifies the login the token was issued for, so revoking the
+	// session invalidates the token before it expires
+	SessionID string `json:"sid,omitempty"`
+	// OrganizationID is the organization requests are scoped to unless they select another one
+	OrganizationID int64 `json:"org_id,omitempty"`
+	// ImpersonatorID is the admin acting as UserID with an impersonation token
+	ImpersonatorID int64 `json:"impersonator_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasPermission reports whether the claims grant the given permission
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+// IsImpersonation reports whether the token was issued to an admin impersonating the user
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatorID != 0
+}
+
+// JWTManager issues and verifies signed access tokens
+type JWTManager struct {
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	issuer    string
+	ttl       time.Duration
+}
+
+// NewJWTManager creates a JWTManager based on the configured algorithm.
+// HS256 uses JWT_SECRET, RS256 and EdDSA load PEM keys from
+// JWT_PRIVATE_KEY_PATH and JWT_PUBLIC_KEY_PATH.
+func NewJWTManager(cfg config.Config) (*JWTManager, error) {
+	m := &JWTManager{
+		issuer: cfg.JWTIssuer,
+		ttl:    cfg.JWTAccessTokenTTL,
+	}
+	if m.ttl <= 0 {
+		m.ttl = defaultAccessTokenTTL
+	}
+
+	algorithm := cfg.JWTAlgorithm
+	if algorithm == "" {
+		algorithm = AlgorithmHS256
+	}
+
+	switch algorithm {
+	case AlgorithmHS256:
+		if cfg.JWTSecret == "" {
+			return nil, fmt.Errorf("JWT_SECRET is required for %s", algorithm)
+		}
+		m.method = jwt.SigningMethodHS256
+		m.signKey = []byte(cfg.JWTSecret)
+		m.verifyKey = []byte(cfg.JWTSecret)
+
+	case AlgorithmRS256:
+		privatePEM, publicPEM, err := readKeyPair(cfg)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA private key: %w", err)
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA public key: %w", err)
+		}
+		m.method = jwt.SigningMethodRS256
+		m.signKey = privateKey
+		m.verifyKey = publicKey
+
+	case AlgorithmEdDSA:
+		privatePEM, publicPEM, err := readKeyPair(cfg)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Ed25519 private key: %w", err)
+		}
+		publicKey, err := jwt.ParseEdPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Ed25519 public key: %w", err)
+		}
+		m.method = jwt.SigningMethodEdDSA
+		m.signKey = privateKey
+		m.verifyKey = publicKey
+
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+
+	return m, nil
+}
+
+// TTL returns the lifetime of issued access tokens
+func (m *JWTManager) TTL() time.Duration {
+	return m.ttl
+}
+
+// Generate issues a signed access token carrying the given claims.
+// Registered claims (sub, iss, iat, nbf, exp) are filled in by the manager.
+func (m *JWTManager) Generate(claims Claims) (string, time.Time, error) {
+	return m.GenerateWithTTL(claims, m.ttl)
+}
+
+// GenerateWithTTL issues a signed access token like Generate, valid for the given lifetime
+func (m *JWTManager) GenerateWithTTL(claims Claims, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Subject:   strconv.FormatInt(claims.UserID, 10),
+		Issuer:    m.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
+
+// Verify parses the token, checks its signature and registered claims,
+// and returns the embedded claims
+func (m *JWTManager) Verify(tokenString string) (*Claims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{m.method.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if m.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(m.issuer))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		return m.verifyKey, nil
+	}, opts...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+func readKeyPair(cfg config.Config) ([]byte, []byte, error) {
+	if cfg.JWTPrivateKeyPath == "" || cfg.JWTPublicKeyPath == "" {
+		return nil, nil, fmt.Errorf("JWT_PRIVATE_KEY_PATH and JWT_PUBLIC_KEY_PATH are required for %s", cfg.JWTAlgorithm)
+	}
+
+	privatePEM, err := os.ReadFile(cfg.JWTPrivateKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	publicPEM, err := os.ReadFile(cfg.JWTPublicKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	return privatePEM, publicPEM, nil
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC 6238 Appendix B vectors use 8 digits, 6 digit codes are their last 6 digits
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestGenerateTOTPCode(t *testing.T) {
+	for _, v := range rfc6238Vectors {
+		code, err := GenerateTOTPCode(rfc6238Secret, time.Unix(v.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != v.code {
+			t.Errorf("GenerateTOTPCode at %d = %s, want %s", v.unix, code, v.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	for _, v := range rfc6238Vectors {
+		at := time.Unix(v.unix, 0)
+		step, ok := ValidateTOTP(rfc6238Secret, v.code, at)
+		if !ok || step != TOTPStep(at) {
+			t.Errorf("ValidateTOTP at %d = %d, %v, want %d, true", v.unix, step, ok, TOTPStep(at))
+		}
+	}
+
+	at := time.Unix(1111111111, 0)
+	tests := []struct {
+		name string
+		at   time.Time
+		ok   bool
+	}{
+		{"code of the previous period", at.Add(totpPeriod * time.Second), true},
+		{"code of the next period", at.Add(-totpPeriod * time.Second), true},
+		{"code of two periods before", at.Add(2 * totpPeriod * time.Second), false},
+		{"code of two periods after", at.Add(-2 * totpPeriod * time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfc6238Secret, "050471", tt.at)
+			if ok != tt.ok {
+				t.Fatalf("ValidateTOTP = %v, want %v", ok, tt.ok)
+			}
+			if ok && step != TOTPStep(at) {
+				t.Errorf("step = %d, want the step of the code %d", step, TOTPStep(at))
+			}
+		})
+	}
+
+	for _, code := range []string{"", "50471", "0504710", "abcdef"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, code, at); ok {
+			t.Errorf("ValidateTOTP(%q) accepted", code)
+		}
+	}
+	if _, ok := ValidateTOTP("not base32!", "050471", at); ok {
+		t.Error("ValidateTOTP with an invalid secret accepted")
+	}
+}
+
+func TestValidateTOTPLowercaseSecret(t *testing.T) {
+	if _, ok := ValidateTOTP("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "005924", time.Unix(1234567890, 0)); !ok {
+		t.Error("ValidateTOTP rejected the lowercase secret")
+	}
+}
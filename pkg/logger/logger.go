@@ -40,14 +40,31 @@ func (l *Logger) GetZapLogger() *zap.Logger {
 	return l.sugar.Desugar()
 }
 
-// withTraceID adds trace_id field to logger if available in context
-func (l *Logger) withTraceID(ctx context.Context) *zap.SugaredLogger {
+type fieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx whose log lines carry the given key-value pairs
+// in addition to the fields already stored in ctx.
+// Example: ctx = logger.ContextWithFields(ctx, "user_id", 42)
+func ContextWithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	fields, _ := ctx.Value(fieldsKey{}).([]any)
+	merged := make([]any, 0, len(fields)+len(keysAndValues))
+	merged = append(merged, fields...)
+	merged = append(merged, keysAndValues...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// withContext adds the trace_id field and the fields stored by ContextWithFields, if available in context
+func (l *Logger) withContext(ctx context.Context) *zap.SugaredLogger {
+	sugar := l.sugar
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		traceID := span.SpanContext().TraceID().String()
-		return l.sugar.With("trace_id", traceID)
+		sugar = sugar.With("trace_id", traceID)
+	}
+	if fields, ok := ctx.Value(fieldsKey{}).([]any); ok {
+		sugar = sugar.With(fields...)
 	}
-	return l.sugar
+	return sugar
 }
 
 // WithFields creates a new logger with additional fields
@@ -66,41 +83,41 @@ func (l *Logger) WithFields(fields map[string]any) *Logger {
 }
 
 func (l *Logger) Info(ctx context.Context, v ...any) {
-	l.withTraceID(ctx).Info(v...)
+	l.withContext(ctx).Info(v...)
 }
 
 func (l *Logger) Error(ctx context.Context, v ...any) {
-	l.withTraceID(ctx).Error(v...)
+	l.withContext(ctx).Error(v...)
 }
 
 func (l *Logger) Warn(ctx context.Context, v ...any) {
-	l.withTraceID(ctx).Warn(v...)
+	l.withContext(ctx).Warn(v...)
 }
 
 func (l *Logger) Debug(ctx context.Context, v ...any) {
-	l.withTraceID(ctx).Debug(v...)
+	l.withContext(ctx).Debug(v...)
 }
 
 func (l *Logger) Fatal(ctx context.Context, v ...any) {
-	l.withTraceID(ctx).Fatal(v...)
+	l.withContext(ctx).Fatal(v...)
 }
 
 func (l *Logger) Infof(ctx context.Context, format string, v ...any) {
-	l.withTraceID(ctx).Infof(format, v...)
+	l.withContext(ctx).Infof(format, v...)
 }
 
 func (l *Logger) Errorf(ctx context.Context, format string, v ...any) {
-	l.withTraceID(ctx).Errorf(format, v...)
+	l.withContext(ctx).Errorf(format, v...)
 }
 
 func (l *Logger) Warnf(ctx context.Context, format string, v ...any) {
-	l.withTraceID(ctx).Warnf(format, v...)
+	l.withContext(ctx).Warnf(format, v...)
 }
 
 func (l *Logger) Debugf(ctx context.Context, format string, v ...any) {
-	l.withTraceID(ctx).Debugf(format, v...)
+	l.withContext(ctx).Debugf(format, v...)
 }
 
 func (l *Logger) Fatalf(ctx context.Context, format string, v ...any) {
-	l.withTraceID(ctx).Fatalf(format, v...)
+	l.withContext(ctx).Fatalf(format, v...)
 }
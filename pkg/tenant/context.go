@@ -0,0 +1,24 @@
+package tenant
+
+import "context"
+
+// contextKey is a custom type for context keys to avoid collisions
+type contextKey string
+
+const (
+	// organizationIDKey is the context key for storing the organization a request is scoped to
+	organizationIDKey contextKey = "tenant_organization_id"
+)
+
+// WithOrganizationID returns a copy of ctx scoped to the organization.
+// Repositories of tenant data only see rows of that organization.
+func WithOrganizationID(ctx context.Context, organizationID int64) context.Context {
+	return context.WithValue(ctx, organizationIDKey, organizationID)
+}
+
+// OrganizationIDFromContext retrieves the organization the context is scoped to, if any.
+// Contexts without one, such as logins and background tasks, are not scoped.
+func OrganizationIDFromContext(ctx context.Context) (int64, bool) {
+	organizationID, ok := ctx.Value(organizationIDKey).(int64)
+	return organizationID, ok && organizationID != 0
+}
@@ -0,0 +1,13 @@
+package utils
+
+import "fmt"
+
+// CacheKey returns the key a GET response to the given request URI is cached under
+func CacheKey(requestURI string) string {
+	return fmt.Sprintf("cache:%s", requestURI)
+}
+
+// UserCacheKey returns the cache key of GET /api/v1/users/:id
+func UserCacheKey(userID int64) string {
+	return CacheKey(fmt.Sprintf("/api/v1/users/%d", userID))
+}
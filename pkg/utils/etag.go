@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// FormatETag formats a row version as a strong entity tag, e.g. "3"
+func FormatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatch is a parsed If-Match request header
+type IfMatch struct {
+	// Present is false when the header was not sent
+	Present bool
+	// Any is true for "If-Match: *"
+	Any bool
+	// Versions holds the versions of every strong entity tag in the header
+	Versions []int64
+}
+
+// ParseIfMatch parses an If-Match header value.
+// Weak tags and tags that are not versions never match, as If-Match uses strong comparison.
+func ParseIfMatch(header string) IfMatch {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return IfMatch{}
+	}
+
+	ifMatch := IfMatch{Present: true}
+	if header == "*" {
+		ifMatch.Any = true
+		return ifMatch
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		ifMatch.Versions = append(ifMatch.Versions, version)
+	}
+
+	return ifMatch
+}
+
+// Matches reports whether the precondition holds for the current version.
+// An absent header always matches.
+func (m IfMatch) Matches(version int64) bool {
+	if !m.Present || m.Any {
+		return true
+	}
+	for _, v := range m.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
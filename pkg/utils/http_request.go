@@ -43,14 +43,21 @@ const (
 	MethodPatch  = "PATCH"
 	MethodDelete = "DELETE"
 
-	ContentTypeJson = "application/json"
-	ContentTypeForm = "application/x-www-form-urlencoded"
-	ContentTypeText = "text/plain"
-	ContentTypeXml  = "application/xml"
-
-	HeaderContentType   = "Content-Type"
-	HeaderAccept        = "Accept"
-	HeaderAuthorization = "Authorization"
+	ContentTypeJson           = "application/json"
+	ContentTypeMergePatchJson = "application/merge-patch+json"
+	ContentTypeProblemJson    = "application/problem+json"
+	ContentTypeForm           = "application/x-www-form-urlencoded"
+	ContentTypeText           = "text/plain"
+	ContentTypeXml            = "application/xml"
+
+	HeaderContentType    = "Content-Type"
+	HeaderAccept         = "Accept"
+	HeaderAcceptLanguage = "Accept-Language"
+	HeaderAuthorization  = "Authorization"
+	HeaderAPIKey         = "X-API-Key"
+	HeaderRetryAfter     = "Retry-After"
+	HeaderRequestID      = "X-Request-ID"
+	HeaderOrganizationID = "X-Organization-ID"
 )
 
 // SendRequest sends an HTTP request using the provided config and context
@@ -145,7 +152,7 @@ func SendRequest(ctx context.Context, config HttpRequestConfig) (*HttpResponse,
 	// Set status code di span
 	span.SetAttributes(
 		attribute.Int("http.status_code", resp.StatusCode),
-		attribute.String("http.response_body", string(respBody)),
+		attribute.String("http.response_body", string(redactBody(respBody, resp.Header.Get(HeaderContentType)))),
 	)
 
 	return &HttpResponse{
@@ -215,6 +222,8 @@ func isSensitiveKey(key string) bool {
 		"token",
 		"access_token",
 		"refresh_token",
+		"id_token",
+		"code_verifier",
 		"client_secret",
 		"secret":
 		return true
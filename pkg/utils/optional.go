@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Optional is a JSON field that distinguishes "absent" from "null" from a value,
+// as needed by JSON Merge Patch (RFC 7396) where null means "remove".
+// The zero value is an absent field.
+type Optional[T any] struct {
+	// Set is true when the field was present in the document, including as null
+	Set bool
+	// Null is true when the field was explicitly set to null
+	Null bool
+	// Value holds the decoded value when Set and not Null
+	Value T
+}
+
+// UnmarshalJSON is only called for fields present in the document
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// HasValue reports whether the field was set to a non-null value
+func (o Optional[T]) HasValue() bool {
+	return o.Set && !o.Null
+}
+
+// RegisterOptionalTypes lets struct tags on Optional fields validate the inner value.
+// Absent and null fields validate as nil, so "omitempty" skips them.
+func RegisterOptionalTypes(v *validator.Validate) {
+	v.RegisterCustomTypeFunc(optionalValue[string], Optional[string]{})
+}
+
+func optionalValue[T any](field reflect.Value) any {
+	if o, ok := field.Interface().(Optional[T]); ok && o.HasValue() {
+		return o.Value
+	}
+	return nil
+}
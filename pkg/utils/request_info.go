@@ -0,0 +1,25 @@
+package utils
+
+import "context"
+
+// requestInfoKey is the context key for storing the request info
+type requestInfoKey struct{}
+
+// RequestInfo identifies the HTTP request a context belongs to
+type RequestInfo struct {
+	// RequestID is the X-Request-ID of the request
+	RequestID string
+	// IPAddress is the client IP
+	IPAddress string
+}
+
+// WithRequestInfo returns a copy of ctx carrying the request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext retrieves the request info from context if it exists
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
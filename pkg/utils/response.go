@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,6 +13,48 @@ type Response struct {
 	Message string `json:"message,omitempty"`
 	Data    any    `json:"data,omitempty"`
 	Error   string `json:"error,omitempty"`
+	// Errors lists the fields of the request that failed validation
+	Errors []ErrorDetail `json:"errors,omitempty"`
+}
+
+// ProblemDetails is an RFC 7807 error response, sent as application/problem+json
+type ProblemDetails struct {
+	// Type is a URI identifying the kind of problem, about:blank when no base URL is configured
+	Type string `json:"type"`
+	// Title is the status text, the same for every problem of the type
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// RequestID and Errors are extension members
+	RequestID string        `json:"request_id,omitempty"`
+	Errors    []ErrorDetail `json:"errors,omitempty"`
+}
+
+// ErrorDetail describes one thing wrong with a request. Field errors name the
+// field by its JSON path with the failed rule and its parameter.
+type ErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ErrorDetailer is implemented by errors listing several things wrong with a request.
+// ErrorResponse sends the list as the errors member of both response formats.
+type ErrorDetailer interface {
+	ErrorDetails() []ErrorDetail
+}
+
+// ErrorOptions selects how ErrorResponse writes errors, middleware.ErrorFormat stores them per request
+type ErrorOptions struct {
+	// ProblemDetails always answers with problem details, otherwise only callers
+	// accepting application/problem+json get them
+	ProblemDetails bool
+	// ProblemTypeBaseURL prefixes the type URI of problems, e.g. https://example.com/problems/
+	ProblemTypeBaseURL string
+	// HideInternalErrors omits the error text of 5xx responses, which may hold SQL or other internals
+	HideInternalErrors bool
 }
 
 type PaginationResponse struct {
@@ -29,6 +73,7 @@ type Pagination struct {
 
 const (
 	CtxResponseMessageKey = "response_message"
+	CtxErrorOptionsKey    = "error_options"
 )
 
 func SuccessResponse(c *gin.Context, statusCode int, message string, data any) {
@@ -40,7 +85,25 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data any) {
 	})
 }
 
+// ErrorResponse writes an error as a Response envelope, or as problem details when
+// enabled by the ErrorOptions of the request or asked for in the Accept header
 func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
+	var options ErrorOptions
+	if value, ok := c.Get(CtxErrorOptionsKey); ok {
+		options, _ = value.(ErrorOptions)
+	}
+	if err != nil && statusCode >= http.StatusInternalServerError && options.HideInternalErrors {
+		err = nil
+	}
+
+	c.Set(CtxResponseMessageKey, message)
+
+	if options.ProblemDetails || strings.Contains(c.GetHeader(HeaderAccept), ContentTypeProblemJson) {
+		c.Header(HeaderContentType, ContentTypeProblemJson)
+		c.JSON(statusCode, newProblemDetails(c, options, statusCode, message, err))
+		return
+	}
+
 	response := Response{
 		Success: false,
 		Message: message,
@@ -49,11 +112,45 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 	if err != nil {
 		response.Error = err.Error()
 	}
+	var detailer ErrorDetailer
+	if errors.As(err, &detailer) {
+		response.Errors = detailer.ErrorDetails()
+	}
 
-	c.Set(CtxResponseMessageKey, message)
 	c.JSON(statusCode, response)
 }
 
+func newProblemDetails(c *gin.Context, options ErrorOptions, statusCode int, message string, err error) ProblemDetails {
+	title := http.StatusText(statusCode)
+	problem := ProblemDetails{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   statusCode,
+		Detail:   message,
+		Instance: c.Request.URL.Path,
+	}
+	if options.ProblemTypeBaseURL != "" && title != "" {
+		problem.Type = strings.TrimSuffix(options.ProblemTypeBaseURL, "/") + "/" + problemSlug(title)
+	}
+	if info, ok := RequestInfoFromContext(c.Request.Context()); ok {
+		problem.RequestID = info.RequestID
+	}
+
+	var detailer ErrorDetailer
+	switch {
+	case errors.As(err, &detailer):
+		problem.Errors = detailer.ErrorDetails()
+	case err != nil:
+		problem.Errors = []ErrorDetail{{Message: err.Error()}}
+	}
+	return problem
+}
+
+// problemSlug turns a status text into the last segment of a type URI, e.g. "Not Found" into "not-found"
+func problemSlug(title string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.ToLower(title), "'", ""), " ", "-")
+}
+
 func PaginatedResponse(c *gin.Context, data any, pagination Pagination) {
 	c.Set(CtxResponseMessageKey, "success")
 	c.JSON(http.StatusOK, PaginationResponse{
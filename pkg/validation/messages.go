@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultLocale is used when the caller accepts none of the supported languages
+const DefaultLocale = "en"
+
+// defaultMessageKey is the message of rules without a message of their own
+const defaultMessageKey = "default"
+
+// messages are the field error messages by locale and rule. {field}, {param} and {rule} are
+// replaced with the field path, the rule parameter and the rule. Rules whose meaning depends on
+// the field type have one message per type suffixed with .string, .items or .number.
+var messages = map[string]map[string]string{
+	"en": {
+		"required":        "{field} is required",
+		"required_unless": "{field} is required",
+		"email":           "{field} must be a valid email address",
+		"min.string":      "{field} must be at least {param} characters long",
+		"min.items":       "{field} must contain at least {param} items",
+		"min.number":      "{field} must be {param} or greater",
+		"max.string":      "{field} must be at most {param} characters long",
+		"max.items":       "{field} must contain at most {param} items",
+		"max.number":      "{field} must be {param} or less",
+		"len.string":      "{field} must be exactly {param} characters long",
+		"len.items":       "{field} must contain exactly {param} items",
+		"len.number":      "{field} must be {param}",
+		"numeric":         "{field} must be a number",
+		"oneof":           "{field} must be one of: {param}",
+		"nefield":         "{field} must be different from {param}",
+		TagStrongPassword: "{field} must be at least 8 characters long and contain upper and lower case letters and a digit",
+		TagMaxBytes:       "{field} must be at most {param} bytes long",
+		TagEmailDomain:    "{field} uses an email domain that is not allowed",
+		defaultMessageKey: "{field} failed the {rule} rule",
+	},
+	"id": {
+		"required":        "{field} wajib diisi",
+		"required_unless": "{field} wajib diisi",
+		"email":           "{field} harus berupa alamat email yang valid",
+		"min.string":      "{field} minimal {param} karakter",
+		"min.items":       "{field} minimal berisi {param} item",
+		"min.number":      "{field} minimal {param}",
+		"max.string":      "{field} maksimal {param} karakter",
+		"max.items":       "{field} maksimal berisi {param} item",
+		"max.number":      "{field} maksimal {param}",
+		"len.string":      "{field} harus tepat {param} karakter",
+		"len.items":       "{field} harus berisi tepat {param} item",
+		"len.number":      "{field} harus bernilai {param}",
+		"numeric":         "{field} harus berupa angka",
+		"oneof":           "{field} harus salah satu dari: {param}",
+		"nefield":         "{field} harus berbeda dari {param}",
+		TagStrongPassword: "{field} minimal 8 karakter dan harus berisi huruf besar, huruf kecil, dan angka",
+		TagMaxBytes:       "{field} maksimal {param} byte",
+		TagEmailDomain:    "{field} menggunakan domain email yang tidak diizinkan",
+		defaultMessageKey: "{field} tidak memenuhi aturan {rule}",
+	},
+}
+
+// Locale returns the supported language the Accept-Language header prefers most,
+// or DefaultLocale when there is none. Languages are ranked by their q weight, in
+// header order among equal weights, and languages with q=0 are never chosen.
+func Locale(acceptLanguage string) string {
+	type weighted struct {
+		language string
+		q        float64
+	}
+
+	var candidates []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		language = strings.ToLower(language)
+		if _, ok := messages[language]; !ok {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				parsed, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, weighted{language, q})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b weighted) int {
+		return cmp.Compare(b.q, a.q)
+	})
+	if len(candidates) > 0 {
+		return candidates[0].language
+	}
+	return DefaultLocale
+}
+
+// message returns the message of the field error in the locale
+func message(locale string, fe validator.FieldError, field string) string {
+	localized, ok := messages[locale]
+	if !ok {
+		localized = messages[DefaultLocale]
+	}
+
+	text, ok := localized[fe.Tag()+"."+kindSuffix(fe.Kind())]
+	if !ok {
+		text, ok = localized[fe.Tag()]
+	}
+	if !ok {
+		text = localized[defaultMessageKey]
+	}
+
+	return strings.NewReplacer(
+		"{field}", field,
+		"{param}", fe.Param(),
+		"{rule}", fe.Tag(),
+	).Replace(text)
+}
+
+// kindSuffix is the message suffix of rules that count characters, items or compare numbers
+func kindSuffix(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return "items"
+	default:
+		return "number"
+	}
+}
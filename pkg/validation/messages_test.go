@@ -0,0 +1,29 @@
+package validation
+
+import "testing"
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", DefaultLocale},
+		{"id", "id"},
+		{"id-ID,en;q=0.8", "id"},
+		{"en;q=0.5,id;q=0.9", "id"},
+		{"en-US;q=0.3, id-ID;Q=0.7, fr", "id"},
+		{"id;q=0,en;q=0.1", "en"},
+		{"id;q=0", DefaultLocale},
+		{"fr,de;q=0.9", DefaultLocale},
+		{"en,id", "en"},
+		{"id;q=0.5,en;q=0.5", "id"},
+		{"id;q=abc,en;q=0.2", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			if got := Locale(tt.acceptLanguage); got != tt.want {
+				t.Errorf("Locale(%q) = %s, want %s", tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
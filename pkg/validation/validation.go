@@ -0,0 +1,169 @@
+// Package validation registers the custom binding rules of the API and turns
+// validator errors into field errors with localized messages.
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-gin-sqlx-template/pkg/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Custom rules usable in binding tags
+const (
+	// TagStrongPassword requires at least strongPasswordMinLength characters
+	// with an upper case letter, a lower case letter and a digit
+	TagStrongPassword = "strong_password"
+	// TagMaxBytes limits the length of a string in bytes rather than characters,
+	// e.g. max_bytes=72 for passwords since bcrypt only hashes the first 72 bytes
+	TagMaxBytes = "max_bytes"
+	// TagEmailDomain rejects emails of the domains in Options.BlockedEmailDomains and their subdomains
+	TagEmailDomain = "email_domain"
+)
+
+const strongPasswordMinLength = 8
+
+// Options configures the custom rules
+type Options struct {
+	// BlockedEmailDomains lists the domains rejected by email_domain, e.g. disposable mail providers
+	BlockedEmailDomains []string
+}
+
+// Register makes field errors use JSON field names and adds the custom rules to v.
+// Call it once at startup, before anything is validated.
+func Register(v *validator.Validate, options Options) error {
+	v.RegisterTagNameFunc(jsonFieldName)
+
+	if err := v.RegisterValidation(TagStrongPassword, strongPassword); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation(TagMaxBytes, maxBytes); err != nil {
+		return err
+	}
+
+	blocked := make(map[string]bool, len(options.BlockedEmailDomains))
+	for _, domain := range options.BlockedEmailDomains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			blocked[domain] = true
+		}
+	}
+	return v.RegisterValidation(TagEmailDomain, func(fl validator.FieldLevel) bool {
+		return !blockedEmailDomain(fl.Field().String(), blocked)
+	})
+}
+
+// jsonFieldName names fields after their JSON key, fields without one keep their Go name
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+func strongPassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if len([]rune(password)) < strongPasswordMinLength {
+		return false
+	}
+
+	var upper, lower, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+func maxBytes(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		return false
+	}
+	return len(fl.Field().String()) <= limit
+}
+
+// blockedEmailDomain reports whether the domain of the email or one of its parents is blocked
+func blockedEmailDomain(email string, blocked map[string]bool) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || len(blocked) == 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for domain != "" {
+		if blocked[domain] {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			break
+		}
+		domain = parent
+	}
+	return false
+}
+
+// Error is a failed validation with the field errors translated for the caller.
+// utils.ErrorResponse sends them as the errors member.
+type Error struct {
+	Err    error
+	Fields []utils.ErrorDetail
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) ErrorDetails() []utils.ErrorDetail {
+	return e.Fields
+}
+
+// FieldErrors returns one field error per failed rule in err with messages in the locale,
+// or nil when err holds no validator errors
+func FieldErrors(err error, locale string) []utils.ErrorDetail {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fields := make([]utils.ErrorDetail, len(validationErrors))
+	for i, fe := range validationErrors {
+		field := fieldPath(fe)
+		fields[i] = utils.ErrorDetail{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: message(locale, fe, field),
+		}
+	}
+	return fields
+}
+
+// fieldPath is the namespace of the field without the name of the validated struct,
+// e.g. operations[0].op
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}